@@ -0,0 +1,80 @@
+package abi
+
+import (
+	"encoding/hex"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
+
+// Parse parses a contract ABI json, groups its entries by type and derives their
+// signatures, function and error selectors and event topics.
+func Parse(abiJSON string) (*types.ABI, error) {
+	entries, err := ParseEntries(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &types.ABI{}
+	for _, entry := range entries {
+		if err = Derive(&entry); err != nil {
+			return nil, err
+		}
+
+		switch entry.Type {
+		case "constructor":
+			constructor := entry
+			parsed.Constructor = &constructor
+		case "fallback":
+			fallback := entry
+			parsed.Fallback = &fallback
+		case "receive":
+			receive := entry
+			parsed.Receive = &receive
+		case "event":
+			parsed.Events = append(parsed.Events, entry)
+		case "error":
+			parsed.Errors = append(parsed.Errors, entry)
+		default:
+			parsed.Functions = append(parsed.Functions, entry)
+		}
+	}
+	return parsed, nil
+}
+
+// Derive fills in the Signature, Selector and Topic of entry and normalises the
+// legacy constant and payable flags into StateMutability.
+func Derive(entry *types.ABIEntry) error {
+	if entry.Type == "" {
+		entry.Type = "function"
+	}
+
+	if entry.StateMutability == "" && (entry.Type == "function" || entry.Type == "constructor" || entry.Type == "fallback") {
+		switch {
+		case entry.Payable:
+			entry.StateMutability = "payable"
+		case entry.Constant:
+			entry.StateMutability = "view"
+		default:
+			entry.StateMutability = "nonpayable"
+		}
+	}
+
+	switch entry.Type {
+	case "function", "error", "event":
+	default:
+		return nil
+	}
+
+	signature, err := Signature(entry.Name, entry.Inputs)
+	if err != nil {
+		return err
+	}
+
+	hash := Keccak256([]byte(signature))
+	entry.Signature = signature
+	if entry.Type == "event" {
+		entry.Topic = "0x" + hex.EncodeToString(hash)
+	} else {
+		entry.Selector = "0x" + hex.EncodeToString(hash[:4])
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := DecodeHex(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatalf("invalid hex: %s", err)
+	}
+	return b
+}
+
+func TestKeccak256(t *testing.T) {
+	tests := map[string]string{
+		"":                                  "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"Transfer(address,address,uint256)": "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+	}
+
+	for input, want := range tests {
+		if got := hex.EncodeToString(Keccak256([]byte(input))); got != want {
+			t.Fatalf("keccak256(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestAddressHex(t *testing.T) {
+	address, err := HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if address.Hex() != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Fatalf("unexpected checksum: %s", address.Hex())
+	}
+}
+
+func TestDecodeArguments(t *testing.T) {
+	args := []types.ABIArgument{
+		{Name: "a", Type: "uint"},
+		{Name: "b", Type: "uint32[]"},
+		{Name: "c", Type: "bytes10"},
+		{Name: "d", Type: "bytes"},
+	}
+
+	signature, _ := Signature("f", args)
+	if selector := hex.EncodeToString(Keccak256([]byte(signature))[:4]); selector != "8be65246" {
+		t.Fatalf("unexpected selector %s for %s", selector, signature)
+	}
+
+	data := mustHex(t, `
+		0000000000000000000000000000000000000000000000000000000000000123
+		0000000000000000000000000000000000000000000000000000000000000080
+		3132333435363738393000000000000000000000000000000000000000000000
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000456
+		0000000000000000000000000000000000000000000000000000000000000789
+		000000000000000000000000000000000000000000000000000000000000000d
+		48656c6c6f2c20776f726c642100000000000000000000000000000000000000`)
+
+	values, err := DecodeArguments(args, data)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got := fmt.Sprintf("%v %v %s %s", values[0].Value, values[1].Value, values[2].Value, values[3].Value)
+	if got != "291 [1110 1929] 1234567890 Hello, world!" {
+		t.Fatalf("unexpected values: %s", got)
+	}
+}
+
+func TestDecodeNestedDynamic(t *testing.T) {
+	args := []types.ABIArgument{{Type: "uint256[][]"}, {Type: "string[]"}}
+	data := mustHex(t, `
+		0000000000000000000000000000000000000000000000000000000000000040
+		0000000000000000000000000000000000000000000000000000000000000140
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000040
+		00000000000000000000000000000000000000000000000000000000000000a0
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000002
+		0000000000000000000000000000000000000000000000000000000000000001
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000003
+		0000000000000000000000000000000000000000000000000000000000000060
+		00000000000000000000000000000000000000000000000000000000000000a0
+		00000000000000000000000000000000000000000000000000000000000000e0
+		0000000000000000000000000000000000000000000000000000000000000003
+		6f6e650000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000003
+		74776f0000000000000000000000000000000000000000000000000000000000
+		0000000000000000000000000000000000000000000000000000000000000005
+		7468726565000000000000000000000000000000000000000000000000000000`)
+
+	values, err := DecodeArguments(args, data)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if got := fmt.Sprintf("%v %v", values[0].Value, values[1].Value); got != "[[1 2] [3]] [one two three]" {
+		t.Fatalf("unexpected values: %s", got)
+	}
+}
+
+func TestDecodeNegativeInt(t *testing.T) {
+	values, err := DecodeArguments([]types.ABIArgument{{Type: "int8"}}, mustHex(t, strings.Repeat("ff", 32)))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if values[0].Value.(*big.Int).Int64() != -1 {
+		t.Fatalf("unexpected value: %v", values[0].Value)
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	entries, err := ParseEntries(`[{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]}]`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	event, err := DecodeLog(entries, []string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x0000000000000000000000000000000000000000000000000000000000000001",
+	}, "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got := fmt.Sprintf("%s %v %v %v", event.Signature, event.Args[0].Value, event.Args[1].Value, event.Args[2].Value)
+	want := "Transfer(address,address,uint256) 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed 0x0000000000000000000000000000000000000001 1000"
+	if got != want {
+		t.Fatalf("unexpected event: %s", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	parsed, err := Parse(`[
+		{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+		{"inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable","type":"constructor"},
+		{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+		{"inputs":[{"name":"needed","type":"uint256"}],"name":"InsufficientBalance","type":"error"},
+		{"stateMutability":"payable","type":"receive"}
+	]`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	balanceOf := parsed.FunctionBySelector("0x70a08231")
+	if balanceOf == nil || balanceOf.StateMutability != "view" || balanceOf.Signature != "balanceOf(address)" {
+		t.Fatalf("unexpected function: %+v", balanceOf)
+	}
+
+	if parsed.EventByTopic("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef") == nil {
+		t.Fatal("expected the Transfer event")
+	}
+
+	if parsed.Constructor == nil || parsed.Receive == nil || len(parsed.Errors) != 1 || parsed.Errors[0].Selector == "" {
+		t.Fatalf("unexpected abi: %+v", parsed)
+	}
+}
+
+func TestDecodeCall(t *testing.T) {
+	parsed, err := Parse(`[{"type":"function","name":"swap","inputs":[
+		{"name":"path","type":"address[]"},
+		{"name":"order","type":"tuple","components":[{"name":"amount","type":"uint256"},{"name":"memo","type":"string"}]}]}]`)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	selector := parsed.Functions[0].Selector
+	input := selector + strings.Join([]string{
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"6869000000000000000000000000000000000000000000000000000000000000",
+	}, "")
+
+	call, err := DecodeCall(parsed, input)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	order := call.Args[1].Value.([]types.DecodedValue)
+	got := fmt.Sprintf("%s %v %s=%v %s=%v", call.Signature, call.Args[0].Value, order[0].Name, order[0].Value, order[1].Name, order[1].Value)
+	want := "swap(address[],(uint256,string)) [0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed 0x0000000000000000000000000000000000000001] amount=7 memo=hi"
+	if got != want {
+		t.Fatalf("unexpected call: %s", got)
+	}
+}
+
+func TestSignatureDB(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signatures.txt")
+	db, _, err := OpenSignatureDB(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	dump := "# selector,signature\n" +
+		"0xa9059cbb,transfer(address,uint256)\n" +
+		"Transfer(address, address, uint)\n" +
+		"\n" +
+		"swap(address[],(uint256,string))\n"
+	if added, invalid, err := db.Import(strings.NewReader(dump)); err != nil || added != 3 || len(invalid) != 0 {
+		t.Fatalf("unexpected import: %d, %v, %v", added, invalid, err)
+	}
+	added, invalid, err := db.Import(strings.NewReader("transfer(address\nfoo(fixed128x18)\napprove(address,uint256)\n"))
+	if err != nil || added != 1 || len(invalid) != 2 || !strings.HasPrefix(invalid[1].Error(), "line 2:") {
+		t.Fatalf("expected malformed signatures to be skipped, got %d, %v, %v", added, invalid, err)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatalf("close: %s", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+	_, _ = file.WriteString("corrupt(\n")
+	_ = file.Close()
+
+	db, invalid, err = OpenSignatureDB(path)
+	if err != nil || db.Len() != 4 || len(invalid) != 1 || !strings.HasPrefix(invalid[0].Error(), "line 5:") {
+		t.Fatalf("expected the signatures to be persisted and the corrupt line reported, got %d, %v, %v", db.Len(), invalid, err)
+	}
+	defer db.Close()
+
+	call, err := db.DecodeCall("0xa9059cbb" +
+		"0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed" +
+		"00000000000000000000000000000000000000000000000000000000000003e8")
+	if err != nil || call.Signature != "transfer(address,uint256)" || fmt.Sprint(call.Args[1].Value) != "1000" {
+		t.Fatalf("unexpected call: %+v, %v", call, err)
+	}
+
+	event, err := db.DecodeLog([]string{
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x0000000000000000000000000000000000000000000000000000000000000001",
+	}, "0x00000000000000000000000000000000000000000000000000000000000003e8")
+	if err != nil || event.Signature != "Transfer(address,address,uint256)" || fmt.Sprint(event.Args[2].Value) != "1000" {
+		t.Fatalf("unexpected event: %+v, %v", event, err)
+	}
+}
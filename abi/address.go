@@ -0,0 +1,62 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type Address [20]byte
+
+func HexToAddress(s string) (Address, error) {
+	var a Address
+	b, err := DecodeHex(s)
+	if err != nil {
+		return a, err
+	}
+
+	if len(b) != len(a) {
+		return a, fmt.Errorf("invalid address %q", s)
+	}
+	copy(a[:], b)
+	return a, nil
+}
+
+// Hex returns the EIP-55 checksummed form of the address.
+func (a Address) Hex() string {
+	lower := hex.EncodeToString(a[:])
+	hash := Keccak256([]byte(lower))
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c < 'a' {
+			continue
+		}
+
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+func (a Address) String() string {
+	return a.Hex()
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// DecodeHex decodes s with or without its 0x prefix, an odd length is left padded.
+func DecodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "0x"), "0X")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	return hex.DecodeString(s)
+}
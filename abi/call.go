@@ -0,0 +1,54 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
+
+// DecodeCall matches the selector of a transaction input against the functions of
+// parsed and decodes the arguments following it.
+func DecodeCall(parsed *types.ABI, input string) (*types.DecodedCall, error) {
+	data, err := DecodeHex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: input of %d bytes holds no selector", len(data))
+	}
+
+	selector := "0x" + hex.EncodeToString(data[:4])
+	function := parsed.FunctionBySelector(selector)
+	if function == nil {
+		return nil, fmt.Errorf("abi: no function matches selector %s", selector)
+	}
+
+	args, err := DecodeArguments(function.Inputs, data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("abi: decoding %s: %w", function.Signature, err)
+	}
+	return &types.DecodedCall{Name: function.Name, Signature: function.Signature, Selector: selector, Args: args}, nil
+}
+
+// DecodeConstructor decodes the abi-encoded constructor arguments appended to a
+// contract's creation code, as reported in EtherSourceCode.ConstructorArguments.
+func DecodeConstructor(parsed *types.ABI, arguments string) ([]types.DecodedValue, error) {
+	data, err := DecodeHex(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Constructor == nil {
+		if len(data) != 0 {
+			return nil, fmt.Errorf("abi: %d bytes of constructor arguments but no constructor", len(data))
+		}
+		return nil, nil
+	}
+
+	args, err := DecodeArguments(parsed.Constructor.Inputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("abi: decoding constructor: %w", err)
+	}
+	return args, nil
+}
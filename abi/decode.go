@@ -0,0 +1,159 @@
+package abi
+
+import (
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+)
+
+// DecodeArguments decodes data, the ABI encoding of args laid out as a tuple.
+func DecodeArguments(args []types.ABIArgument, data []byte) ([]types.DecodedValue, error) {
+	tuple, err := NewType("tuple", args)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decodeTuple(tuple, data)
+	if err != nil {
+		return nil, err
+	}
+	return values.([]types.DecodedValue), nil
+}
+
+// decode decodes the value of t whose encoding starts at data[0].
+func decode(t *Type, data []byte) (interface{}, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		value := new(big.Int).SetBytes(word)
+		// signed values are sign extended to the whole word
+		if t.Kind == IntKind && value.Bit(255) == 1 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return value, nil
+	case BoolKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return word[31] == 1, nil
+	case AddressKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		var address Address
+		copy(address[:], word[12:])
+		return address, nil
+	case FixedBytesKind, FunctionKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, word[:t.Size]...), nil
+	case BytesKind, StringKind:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if 32+length > len(data) {
+			return nil, fmt.Errorf("abi: %s of length %d overflows %d bytes", t, length, len(data))
+		}
+		if t.Kind == StringKind {
+			return string(data[32 : 32+length]), nil
+		}
+		return append([]byte{}, data[32:32+length]...), nil
+	case SliceKind:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if length > len(data) {
+			return nil, fmt.Errorf("abi: %s of length %d overflows %d bytes", t, length, len(data))
+		}
+		return decodeList(t.Elem, length, data[32:])
+	case ArrayKind:
+		return decodeList(t.Elem, t.Size, data)
+	case TupleKind:
+		return decodeTuple(t, data)
+	default:
+		return nil, fmt.Errorf("abi: cannot decode %s", t)
+	}
+}
+
+func decodeTuple(t *Type, data []byte) (interface{}, error) {
+	values := make([]types.DecodedValue, 0, len(t.Components))
+	elems, err := decodeHeads(t.Components, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, elem := range elems {
+		values = append(values, types.DecodedValue{Name: t.Names[i], Type: t.Components[i].String(), Value: elem})
+	}
+	return values, nil
+}
+
+func decodeList(elem *Type, length int, data []byte) (interface{}, error) {
+	elems := make([]*Type, length)
+	for i := range elems {
+		elems[i] = elem
+	}
+	return decodeHeads(elems, data)
+}
+
+// decodeHeads decodes consecutive heads, offsets of dynamic values are relative to data[0].
+func decodeHeads(elems []*Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(elems))
+	pos := 0
+	for _, elem := range elems {
+		var value interface{}
+		var err error
+		if elem.dynamic() {
+			var offset int
+			if offset, err = readLength(data, pos); err != nil {
+				return nil, err
+			}
+			if offset > len(data) {
+				return nil, fmt.Errorf("abi: offset %d of %s overflows %d bytes", offset, elem, len(data))
+			}
+			value, err = decode(elem, data[offset:])
+		} else {
+			if pos > len(data) {
+				return nil, fmt.Errorf("abi: %s overflows %d bytes", elem, len(data))
+			}
+			value, err = decode(elem, data[pos:])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+		pos += elem.headSize()
+	}
+	return values, nil
+}
+
+func readWord(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos+32 > len(data) {
+		return nil, fmt.Errorf("abi: reading word at %d overflows %d bytes", pos, len(data))
+	}
+	return data[pos : pos+32], nil
+}
+
+// readLength reads a length or an offset, which has to fit the data it points into.
+func readLength(data []byte, pos int) (int, error) {
+	word, err := readWord(data, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	value := new(big.Int).SetBytes(word)
+	if !value.IsInt64() || value.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("abi: length %s at %d overflows %d bytes", value, pos, len(data))
+	}
+	return int(value.Int64()), nil
+}
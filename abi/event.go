@@ -0,0 +1,124 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
+
+// ParseEntries parses a contract ABI json, as returned by GetABIData.
+func ParseEntries(abiJSON string) ([]types.ABIEntry, error) {
+	var entries []types.ABIEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	return entries, nil
+}
+
+// EventTopic returns the keccak256 hash of the event signature, the topic0 of its logs.
+func EventTopic(event types.ABIEntry) ([]byte, error) {
+	signature, err := Signature(event.Name, event.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	return Keccak256([]byte(signature)), nil
+}
+
+// DecodeLog matches topics[0] against the events of entries and decodes the log.
+// Indexed arguments of dynamic types are only stored as their keccak256 hash,
+// their value is that 32 bytes hash.
+func DecodeLog(entries []types.ABIEntry, topics []string, data string) (*types.DecodedEvent, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("abi: log without topics")
+	}
+
+	topic0, err := DecodeHex(topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Type != "event" || entry.Anonymous {
+			continue
+		}
+
+		topic, err := EventTopic(entry)
+		if err != nil || !bytes.Equal(topic, topic0) {
+			continue
+		}
+		return decodeEvent(entry, topics[1:], data)
+	}
+	return nil, fmt.Errorf("abi: no event matches topic %s", topics[0])
+}
+
+func decodeEvent(event types.ABIEntry, topics []string, data string) (*types.DecodedEvent, error) {
+	signature, err := Signature(event.Name, event.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexed, unindexed []types.ABIArgument
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		} else {
+			unindexed = append(unindexed, input)
+		}
+	}
+
+	if len(indexed) != len(topics) {
+		return nil, fmt.Errorf("abi: %s expects %d indexed topics, got %d", signature, len(indexed), len(topics))
+	}
+
+	raw, err := DecodeHex(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := DecodeArguments(unindexed, raw)
+	if err != nil {
+		return nil, fmt.Errorf("abi: decoding %s: %w", signature, err)
+	}
+
+	decoded := &types.DecodedEvent{Name: event.Name, Signature: signature}
+	topicIdx, valueIdx := 0, 0
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			decoded.Args = append(decoded.Args, values[valueIdx])
+			valueIdx++
+			continue
+		}
+
+		value, err := decodeTopic(input, topics[topicIdx])
+		if err != nil {
+			return nil, fmt.Errorf("abi: decoding %s: %w", signature, err)
+		}
+		decoded.Args = append(decoded.Args, value)
+		topicIdx++
+	}
+	return decoded, nil
+}
+
+func decodeTopic(input types.ABIArgument, topic string) (types.DecodedValue, error) {
+	t, err := NewType(input.Type, input.Components)
+	if err != nil {
+		return types.DecodedValue{}, err
+	}
+
+	raw, err := DecodeHex(topic)
+	if err != nil {
+		return types.DecodedValue{}, err
+	}
+
+	value := types.DecodedValue{Name: input.Name, Type: t.String()}
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind, ArrayKind, TupleKind:
+		value.Value = raw
+	default:
+		if value.Value, err = decode(t, raw); err != nil {
+			return types.DecodedValue{}, err
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,13 @@
+package abi
+
+import "golang.org/x/crypto/sha3"
+
+// Keccak256 is the legacy Keccak-256 ethereum hashes with, it predates the
+// padding change of SHA3-256.
+func Keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hash.Write(d)
+	}
+	return hash.Sum(nil)
+}
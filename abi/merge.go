@@ -0,0 +1,40 @@
+package abi
+
+import (
+	"encoding/json"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
+
+// Merge returns the entries of every list in order, dropping the entries whose type
+// and signature an earlier list already holds, so the first list wins on conflicts.
+func Merge(lists ...[]types.ABIEntry) []types.ABIEntry {
+	var merged []types.ABIEntry
+	seen := make(map[string]bool)
+	for _, entries := range lists {
+		for _, entry := range entries {
+			key := entry.Type
+			if signature, err := Signature(entry.Name, entry.Inputs); err == nil && entry.Name != "" {
+				key += ":" + signature
+			}
+
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, entry)
+		}
+	}
+	return merged
+}
+
+func MarshalEntries(entries []types.ABIEntry) (string, error) {
+	if entries == nil {
+		entries = []types.ABIEntry{}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,313 @@
+package abi
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SignatureDB maps 4-byte selectors and event topics to text signatures such as
+// "transfer(address,uint256)". Text signatures carry no hint whether they name a
+// function or an event, so every signature is indexed under both its selector
+// and its topic. A SignatureDB opened on a file appends every new signature to it,
+// one per line, the same format Import reads.
+type SignatureDB struct {
+	mu        sync.RWMutex
+	selectors map[string][]string
+	topics    map[string]string
+	file      *os.File
+}
+
+func NewSignatureDB() *SignatureDB {
+	return &SignatureDB{selectors: make(map[string][]string), topics: make(map[string]string)}
+}
+
+// OpenSignatureDB loads the signatures stored at path, creating the file when it
+// does not exist, and persists signatures added later to it. Lines of the file
+// that do not parse are skipped and returned, see Import.
+func OpenSignatureDB(path string) (*SignatureDB, []error, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := NewSignatureDB()
+	_, invalid, err := db.Import(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, invalid, err
+	}
+	db.file = file
+	return db, invalid, nil
+}
+
+func (db *SignatureDB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.file == nil {
+		return nil
+	}
+	err := db.file.Close()
+	db.file = nil
+	return err
+}
+
+// Add normalises signature to its canonical form and stores it, reporting whether
+// it was new.
+func (db *SignatureDB) Add(signature string) (bool, error) {
+	canonical, err := canonicalSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	return db.add(canonical)
+}
+
+func (db *SignatureDB) add(canonical string) (bool, error) {
+	hash := Keccak256([]byte(canonical))
+	topic := "0x" + hex.EncodeToString(hash)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.topics[topic]; ok {
+		return false, nil
+	}
+
+	if db.file != nil {
+		if _, err := db.file.WriteString(canonical + "\n"); err != nil {
+			return false, err
+		}
+	}
+
+	selector := topic[:10]
+	db.topics[topic] = canonical
+	db.selectors[selector] = append(db.selectors[selector], canonical)
+	return true, nil
+}
+
+// AddABI stores the signatures of every function, event and error of parsed.
+func (db *SignatureDB) AddABI(parsed *types.ABI) error {
+	for _, list := range [][]types.ABIEntry{parsed.Functions, parsed.Events, parsed.Errors} {
+		for _, entry := range list {
+			if _, err := db.Add(entry.Signature); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Import reads a signature dump, one signature per line, optionally preceded by
+// its hex selector or topic and a comma, tab or space as in 4byte.directory style
+// exports. The hash is recomputed locally, so only the signature is kept. Blank
+// lines and lines starting with # are skipped, as are signatures that do not
+// parse, such as ones using types this package does not support. It returns the
+// number of new signatures and the error of every line skipped that way.
+func (db *SignatureDB) Import(r io.Reader) (added int, invalid []error, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "0x") {
+			if i := strings.IndexAny(text, ",\t "); i > 0 {
+				text = strings.TrimSpace(text[i+1:])
+			}
+		}
+
+		canonical, err := canonicalSignature(text)
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		ok, err := db.add(canonical)
+		if err != nil {
+			return added, invalid, fmt.Errorf("line %d: %w", line, err)
+		}
+		if ok {
+			added++
+		}
+	}
+	return added, invalid, scanner.Err()
+}
+
+// ImportFile imports the signature dump at path, see Import.
+func (db *SignatureDB) ImportFile(path string) (int, []error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	return db.Import(file)
+}
+
+// Functions returns every signature whose selector is selector, as selectors collide.
+func (db *SignatureDB) Functions(selector string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([]string(nil), db.selectors[strings.ToLower(selector)]...)
+}
+
+func (db *SignatureDB) Event(topic string) (string, bool) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	signature, ok := db.topics[strings.ToLower(topic)]
+	return signature, ok
+}
+
+func (db *SignatureDB) Len() int {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return len(db.topics)
+}
+
+// DecodeCall decodes input against the first signature stored for its selector
+// that it decodes cleanly with. Arguments are unnamed.
+func (db *SignatureDB) DecodeCall(input string) (*types.DecodedCall, error) {
+	data, err := DecodeHex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: input of %d bytes holds no selector", len(data))
+	}
+
+	selector := "0x" + hex.EncodeToString(data[:4])
+	for _, signature := range db.Functions(selector) {
+		name, args, err := ParseSignature(signature)
+		if err != nil {
+			continue
+		}
+
+		values, err := DecodeArguments(args, data[4:])
+		if err != nil {
+			continue
+		}
+		return &types.DecodedCall{Name: name, Signature: signature, Selector: selector, Args: values}, nil
+	}
+	return nil, fmt.Errorf("abi: no known signature matches selector %s", selector)
+}
+
+// DecodeLog decodes a log against the signature stored for topics[0]. Which
+// arguments are indexed is unknown from a text signature, the leading ones are
+// assumed to be, as many as there are topics after topics[0].
+func (db *SignatureDB) DecodeLog(topics []string, data string) (*types.DecodedEvent, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("abi: log without topics")
+	}
+
+	signature, ok := db.Event(topics[0])
+	if !ok {
+		return nil, fmt.Errorf("abi: no known signature matches topic %s", topics[0])
+	}
+
+	name, args, err := ParseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topics)-1 > len(args) {
+		return nil, fmt.Errorf("abi: %s has fewer arguments than indexed topics", signature)
+	}
+
+	for i := range args[:len(topics)-1] {
+		args[i].Indexed = true
+	}
+	return decodeEvent(types.ABIEntry{Type: "event", Name: name, Inputs: args}, topics[1:], data)
+}
+
+func canonicalSignature(signature string) (string, error) {
+	name, args, err := ParseSignature(signature)
+	if err != nil {
+		return "", err
+	}
+	return Signature(name, args)
+}
+
+// ParseSignature splits a text signature such as "swap(address[],(uint256,string))"
+// into its name and unnamed arguments, tuples become components.
+func ParseSignature(signature string) (string, []types.ABIArgument, error) {
+	signature = strings.Join(strings.Fields(signature), "")
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("abi: invalid signature %q", signature)
+	}
+
+	args, err := parseArguments(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("abi: invalid signature %q: %w", signature, err)
+	}
+	return signature[:open], args, nil
+}
+
+func parseArguments(list string) ([]types.ABIArgument, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var args []types.ABIArgument
+	depth, start := 0, 0
+	for i := 0; i <= len(list); i++ {
+		if i < len(list) {
+			switch list[i] {
+			case '(':
+				depth++
+			case ')':
+				if depth--; depth < 0 {
+					return nil, fmt.Errorf("unbalanced parentheses")
+				}
+			}
+			if list[i] != ',' || depth != 0 {
+				continue
+			}
+		}
+
+		arg, err := parseArgument(list[start:i])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		start = i + 1
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	return args, nil
+}
+
+func parseArgument(typ string) (types.ABIArgument, error) {
+	if !strings.HasPrefix(typ, "(") {
+		if _, err := NewType(typ, nil); err != nil {
+			return types.ABIArgument{}, err
+		}
+		return types.ABIArgument{Type: typ}, nil
+	}
+
+	closing := strings.LastIndex(typ, ")")
+	components, err := parseArguments(typ[1:closing])
+	if err != nil {
+		return types.ABIArgument{}, err
+	}
+
+	arg := types.ABIArgument{Type: "tuple" + typ[closing+1:], Components: components}
+	if _, err = NewType(arg.Type, arg.Components); err != nil {
+		return types.ABIArgument{}, err
+	}
+	return arg, nil
+}
@@ -0,0 +1,172 @@
+package abi
+
+import (
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strconv"
+	"strings"
+)
+
+type Kind int
+
+const (
+	UintKind Kind = iota
+	IntKind
+	BoolKind
+	AddressKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	FunctionKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+// Type is a parsed ABI type. Size is the bit size of integers, the byte size of
+// bytesN and the length of fixed arrays.
+type Type struct {
+	Kind       Kind
+	Size       int
+	Elem       *Type
+	Components []*Type
+	Names      []string
+
+	canonical string
+}
+
+// NewType parses typ, components describe the fields when typ is a tuple or an array of tuples.
+func NewType(typ string, components []types.ABIArgument) (*Type, error) {
+	typ = strings.TrimSpace(typ)
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		if open < 0 {
+			return nil, fmt.Errorf("invalid abi type %q", typ)
+		}
+
+		elem, err := NewType(typ[:open], components)
+		if err != nil {
+			return nil, err
+		}
+
+		dim := typ[open+1 : len(typ)-1]
+		if dim == "" {
+			return &Type{Kind: SliceKind, Elem: elem, canonical: elem.canonical + "[]"}, nil
+		}
+
+		size, err := strconv.Atoi(dim)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid array length in abi type %q", typ)
+		}
+		return &Type{Kind: ArrayKind, Size: size, Elem: elem, canonical: elem.canonical + "[" + dim + "]"}, nil
+	}
+
+	switch {
+	case typ == "tuple":
+		t := &Type{Kind: TupleKind}
+		var canonical []string
+		for _, component := range components {
+			ct, err := NewType(component.Type, component.Components)
+			if err != nil {
+				return nil, err
+			}
+			t.Components = append(t.Components, ct)
+			t.Names = append(t.Names, component.Name)
+			canonical = append(canonical, ct.canonical)
+		}
+		t.canonical = "(" + strings.Join(canonical, ",") + ")"
+		return t, nil
+	case typ == "bool":
+		return &Type{Kind: BoolKind, canonical: typ}, nil
+	case typ == "address":
+		return &Type{Kind: AddressKind, Size: 20, canonical: typ}, nil
+	case typ == "string":
+		return &Type{Kind: StringKind, canonical: typ}, nil
+	case typ == "bytes":
+		return &Type{Kind: BytesKind, canonical: typ}, nil
+	case typ == "function":
+		return &Type{Kind: FunctionKind, Size: 24, canonical: typ}, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		kind, bitsStr := IntKind, strings.TrimPrefix(typ, "int")
+		if strings.HasPrefix(typ, "uint") {
+			kind, bitsStr = UintKind, strings.TrimPrefix(typ, "uint")
+		}
+
+		size := 256
+		if bitsStr != "" {
+			var err error
+			if size, err = strconv.Atoi(bitsStr); err != nil || size <= 0 || size > 256 || size%8 != 0 {
+				return nil, fmt.Errorf("invalid integer size in abi type %q", typ)
+			}
+		}
+		prefix := "int"
+		if kind == UintKind {
+			prefix = "uint"
+		}
+		return &Type{Kind: kind, Size: size, canonical: prefix + strconv.Itoa(size)}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size <= 0 || size > 32 {
+			return nil, fmt.Errorf("invalid bytes size in abi type %q", typ)
+		}
+		return &Type{Kind: FixedBytesKind, Size: size, canonical: typ}, nil
+	default:
+		return nil, fmt.Errorf("unsupported abi type %q", typ)
+	}
+}
+
+// String returns the canonical form used in signatures, e.g. uint256 for uint.
+func (t *Type) String() string {
+	return t.canonical
+}
+
+func (t *Type) dynamic() bool {
+	switch t.Kind {
+	case StringKind, BytesKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.dynamic()
+	case TupleKind:
+		for _, c := range t.Components {
+			if c.dynamic() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// headSize is the number of bytes t takes in the head of its enclosing tuple.
+func (t *Type) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, c := range t.Components {
+			size += c.headSize()
+		}
+		return size
+	default:
+		return 32
+	}
+}
+
+// Signature returns name(type1,type2,...) with canonical argument types.
+func Signature(name string, args []types.ABIArgument) (string, error) {
+	var canonical []string
+	for _, arg := range args {
+		t, err := NewType(arg.Type, arg.Components)
+		if err != nil {
+			return "", err
+		}
+		canonical = append(canonical, t.canonical)
+	}
+	return name + "(" + strings.Join(canonical, ",") + ")", nil
+}
@@ -0,0 +1,199 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strings"
+	"sync"
+)
+
+// NamedSource is a source of an Aggregator, Name is what answers are reported under.
+type NamedSource struct {
+	Name   string
+	Source IDataSource
+}
+
+// AggregateError is returned when no source of an Aggregator answered. It
+// unwraps to the last error other than ErrUnsupported, or to ErrUnsupported
+// when no source supports the method.
+type AggregateError struct {
+	Method string
+	Errors []error
+}
+
+func (e *AggregateError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("%s: no sources", e.Method)
+	}
+
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%s: every source failed: %s", e.Method, strings.Join(messages, "; "))
+}
+
+func (e *AggregateError) Unwrap() error {
+	for i := len(e.Errors) - 1; i >= 0; i-- {
+		if !errors.Is(e.Errors[i], ErrUnsupported) {
+			return e.Errors[i]
+		}
+	}
+	return ErrUnsupported
+}
+
+// Aggregator is an IDataSource trying its sources in order until one answers.
+// A source answering ErrUnsupported is not asked for that method again, any
+// other error falls back to the next source. The From variants of its methods
+// also return the name of the source that answered.
+type Aggregator struct {
+	sources []NamedSource
+
+	lock        sync.RWMutex
+	unsupported map[string]map[string]bool // method:source name
+}
+
+func NewAggregator(sources ...NamedSource) *Aggregator {
+	return &Aggregator{sources: sources, unsupported: make(map[string]map[string]bool)}
+}
+
+func (a *Aggregator) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
+	return a.GetMarketInfoForCoinCtx(context.Background())
+}
+
+func (a *Aggregator) GetMarketInfoForCoinCtx(ctx context.Context) ([]*types.MarketInfo, error) {
+	markets, _, err := a.GetMarketInfoForCoinFrom(ctx)
+	return markets, err
+}
+
+// GetMarketInfoForCoinFrom is GetMarketInfoForCoinCtx also returning the name of the source that answered.
+func (a *Aggregator) GetMarketInfoForCoinFrom(ctx context.Context) ([]*types.MarketInfo, string, error) {
+	var markets []*types.MarketInfo
+	source, err := a.try(ctx, "GetMarketInfoForCoin", func(source IDataSource) (err error) {
+		markets, err = source.GetMarketInfoForCoinCtx(ctx)
+		return err
+	})
+	return markets, source, err
+}
+
+func (a *Aggregator) GetTokenInfo(contract string) (*types.TokenInfo, error) {
+	return a.GetTokenInfoCtx(context.Background(), contract)
+}
+
+func (a *Aggregator) GetTokenInfoCtx(ctx context.Context, contract string) (*types.TokenInfo, error) {
+	info, _, err := a.GetTokenInfoFrom(ctx, contract)
+	return info, err
+}
+
+// GetTokenInfoFrom is GetTokenInfoCtx also returning the name of the source that answered.
+func (a *Aggregator) GetTokenInfoFrom(ctx context.Context, contract string) (*types.TokenInfo, string, error) {
+	var info *types.TokenInfo
+	source, err := a.try(ctx, "GetTokenInfo", func(source IDataSource) (err error) {
+		info, err = source.GetTokenInfoCtx(ctx, contract)
+		return err
+	})
+	return info, source, err
+}
+
+func (a *Aggregator) GetSourceCode(contract string) ([]*types.EtherSourceCode, error) {
+	return a.GetSourceCodeCtx(context.Background(), contract)
+}
+
+func (a *Aggregator) GetSourceCodeCtx(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
+	codes, _, err := a.GetSourceCodeFrom(ctx, contract)
+	return codes, err
+}
+
+// GetSourceCodeFrom is GetSourceCodeCtx also returning the name of the source that answered.
+func (a *Aggregator) GetSourceCodeFrom(ctx context.Context, contract string) ([]*types.EtherSourceCode, string, error) {
+	var codes []*types.EtherSourceCode
+	source, err := a.try(ctx, "GetSourceCode", func(source IDataSource) (err error) {
+		codes, err = source.GetSourceCodeCtx(ctx, contract)
+		return err
+	})
+	return codes, source, err
+}
+
+func (a *Aggregator) GetABIData(contract string) (string, error) {
+	return a.GetABIDataCtx(context.Background(), contract)
+}
+
+func (a *Aggregator) GetABIDataCtx(ctx context.Context, contract string) (string, error) {
+	abi, _, err := a.GetABIDataFrom(ctx, contract)
+	return abi, err
+}
+
+// GetABIDataFrom is GetABIDataCtx also returning the name of the source that answered.
+func (a *Aggregator) GetABIDataFrom(ctx context.Context, contract string) (string, string, error) {
+	var abi string
+	source, err := a.try(ctx, "GetABIData", func(source IDataSource) (err error) {
+		abi, err = source.GetABIDataCtx(ctx, contract)
+		return err
+	})
+	return abi, source, err
+}
+
+func (a *Aggregator) IsVerifyCode(contract string) (bool, error) {
+	return a.IsVerifyCodeCtx(context.Background(), contract)
+}
+
+func (a *Aggregator) IsVerifyCodeCtx(ctx context.Context, contract string) (bool, error) {
+	verified, _, err := a.IsVerifyCodeFrom(ctx, contract)
+	return verified, err
+}
+
+// IsVerifyCodeFrom is IsVerifyCodeCtx also returning the name of the source that answered.
+func (a *Aggregator) IsVerifyCodeFrom(ctx context.Context, contract string) (bool, string, error) {
+	var verified bool
+	source, err := a.try(ctx, "IsVerifyCode", func(source IDataSource) (err error) {
+		verified, err = source.IsVerifyCodeCtx(ctx, contract)
+		return err
+	})
+	return verified, source, err
+}
+
+// try calls fn with every source supporting method in turn, stopping at the
+// first one answering, whose name it returns, or when ctx is done.
+func (a *Aggregator) try(ctx context.Context, method string, fn func(IDataSource) error) (string, error) {
+	failed := &AggregateError{Method: method}
+	for _, source := range a.sources {
+		if a.isUnsupported(method, source.Name) {
+			failed.Errors = append(failed.Errors, fmt.Errorf("%s: %w", source.Name, ErrUnsupported))
+			continue
+		}
+
+		err := fn(source.Source)
+		if err == nil {
+			return source.Name, nil
+		}
+
+		if errors.Is(err, ErrUnsupported) {
+			a.setUnsupported(method, source.Name)
+		}
+		failed.Errors = append(failed.Errors, fmt.Errorf("%s: %w", source.Name, err))
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+	return "", failed
+}
+
+func (a *Aggregator) isUnsupported(method, name string) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.unsupported[method][name]
+}
+
+func (a *Aggregator) setUnsupported(method, name string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.unsupported[method] == nil {
+		a.unsupported[method] = make(map[string]bool)
+	}
+	a.unsupported[method][name] = true
+}
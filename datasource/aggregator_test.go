@@ -0,0 +1,92 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"testing"
+)
+
+// fakeSource answers GetABIData with abi or err and supports nothing else.
+type fakeSource struct {
+	abi   string
+	err   error
+	calls int
+}
+
+func (f *fakeSource) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
+	return f.GetMarketInfoForCoinCtx(context.Background())
+}
+
+func (f *fakeSource) GetMarketInfoForCoinCtx(context.Context) ([]*types.MarketInfo, error) {
+	f.calls++
+	return nil, ErrUnsupported
+}
+
+func (f *fakeSource) GetTokenInfo(contract string) (*types.TokenInfo, error) {
+	return f.GetTokenInfoCtx(context.Background(), contract)
+}
+
+func (f *fakeSource) GetTokenInfoCtx(context.Context, string) (*types.TokenInfo, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *fakeSource) GetSourceCode(contract string) ([]*types.EtherSourceCode, error) {
+	return f.GetSourceCodeCtx(context.Background(), contract)
+}
+
+func (f *fakeSource) GetSourceCodeCtx(context.Context, string) ([]*types.EtherSourceCode, error) {
+	return nil, ErrUnsupported
+}
+
+func (f *fakeSource) GetABIData(contract string) (string, error) {
+	return f.GetABIDataCtx(context.Background(), contract)
+}
+
+func (f *fakeSource) GetABIDataCtx(context.Context, string) (string, error) {
+	f.calls++
+	return f.abi, f.err
+}
+
+func (f *fakeSource) IsVerifyCode(contract string) (bool, error) {
+	return f.IsVerifyCodeCtx(context.Background(), contract)
+}
+
+func (f *fakeSource) IsVerifyCodeCtx(context.Context, string) (bool, error) {
+	return false, ErrUnsupported
+}
+
+func TestAggregator(t *testing.T) {
+	limited := &fakeSource{err: ErrRateLimited}
+	unsupported := &fakeSource{err: ErrUnsupported}
+	answering := &fakeSource{abi: "[]"}
+
+	aggregator := NewAggregator(
+		NamedSource{Name: "limited", Source: limited},
+		NamedSource{Name: "unsupported", Source: unsupported},
+		NamedSource{Name: "answering", Source: answering},
+	)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		abi, name, err := aggregator.GetABIDataFrom(ctx, "0xa")
+		if err != nil || abi != "[]" || name != "answering" {
+			t.Fatalf("unexpected abi: %s from %q, %v", abi, name, err)
+		}
+	}
+
+	if limited.calls != 2 || unsupported.calls != 1 || answering.calls != 2 {
+		t.Fatalf("expected unsupported sources to be skipped, got calls %d, %d, %d", limited.calls, unsupported.calls, answering.calls)
+	}
+
+	_, name, err := aggregator.GetMarketInfoForCoinFrom(ctx)
+	var aggregateErr *AggregateError
+	if !errors.Is(err, ErrUnsupported) || !errors.As(err, &aggregateErr) || len(aggregateErr.Errors) != 3 || name != "" {
+		t.Fatalf("expected every source to be unsupported, got: %v", err)
+	}
+
+	var source IDataSource = NewAggregator(NamedSource{Name: "limited", Source: limited}, NamedSource{Name: "unsupported", Source: unsupported})
+	if _, err = source.GetABIData("0xa"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected the rate limit error, got: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package datasource
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores successful GET response bodies keyed by request URL.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// Logger is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+type memoryCache struct {
+	ttl     time.Duration
+	lock    sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache keeps entries in memory for ttl, a ttl <= 0 keeps them forever.
+func NewMemoryCache(ttl time.Duration) Cache {
+	return &memoryCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.lock.RLock()
+	entry, ok := m.entries[key]
+	m.lock.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.lock.Lock()
+		delete(m.entries, key)
+		m.lock.Unlock()
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (m *memoryCache) Set(key string, value []byte) {
+	entry := cacheEntry{value: value}
+	if m.ttl > 0 {
+		entry.expiresAt = time.Now().Add(m.ttl)
+	}
+
+	m.lock.Lock()
+	m.entries[key] = entry
+	m.lock.Unlock()
+}
@@ -0,0 +1,50 @@
+package coingecko
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strconv"
+	"strings"
+)
+
+// checkError maps the error bodies coingecko answers with, {"error": "coin not found"}
+// or {"status": {"error_code": 429, ...}}, it returns nil for any other body.
+func (c *coingecko) checkError(resp []byte) error {
+	cgErr := &types.CoinGeckoError{}
+	if err := json.Unmarshal(resp, cgErr); err != nil {
+		return nil
+	}
+
+	if cgErr.Error == "" && cgErr.Status.ErrorCode == 0 {
+		return nil
+	}
+
+	message := cgErr.Error
+	if message == "" {
+		message = cgErr.Status.ErrorMessage
+	}
+
+	var sentinel error
+	lower := strings.ToLower(message)
+	switch {
+	case cgErr.Status.ErrorCode == 429, strings.Contains(lower, "rate limit"):
+		sentinel = datasource.ErrRateLimited
+	case cgErr.Status.ErrorCode == 401, strings.Contains(lower, "api key"):
+		sentinel = datasource.ErrInvalidAPIKey
+	case strings.Contains(lower, "not found"):
+		sentinel = datasource.ErrNotFound
+	}
+
+	return &datasource.ServiceError{
+		Source:  string(types.CoinGecko),
+		Code:    strconv.Itoa(cgErr.Status.ErrorCode),
+		Message: message,
+		Err:     sentinel,
+	}
+}
+
+func (c *coingecko) misconfigured() error {
+	return fmt.Errorf("%w for %s", datasource.ErrMisconfigured, c.source)
+}
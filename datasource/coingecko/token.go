@@ -1,12 +1,12 @@
 package coingecko
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/ThreeAndTwo/chainscan-api/datasource"
 	"github.com/ThreeAndTwo/chainscan-api/types"
 	"github.com/imroc/req"
-	"golang.org/x/time/rate"
 	"strings"
 	"time"
 )
@@ -14,38 +14,59 @@ import (
 // api document: https://www.coingecko.com/en/api/documentation
 
 type coingecko struct {
-	source      string
-	url         string
-	apiKey      string
-	rateLimiter *rate.Limiter
-	market      *types.MarketMap
+	source    string
+	url       string
+	apiKey    string
+	requester *datasource.Requester
+	market    *types.MarketMap
+	platform  string
 }
 
-func NewCoinGecko(source, url, apiKey string, rate *rate.Limiter, market *types.MarketMap) *coingecko {
+func NewCoinGecko(source, url, apiKey string, requester *datasource.Requester, market *types.MarketMap) *coingecko {
 	if url == "" {
 		url = "https://api.coingecko.com/api/v3/"
 	}
-	return &coingecko{source: source, url: url, apiKey: apiKey, rateLimiter: rate, market: market}
+	return &coingecko{source: source, url: url, apiKey: apiKey, requester: requester, market: market}
+}
+
+// SetPlatform sets the asset platform ID GetTokenInfo looks contracts up on,
+// e.g. "binance-smart-chain", instead of deriving it from the source name.
+func (c *coingecko) SetPlatform(platform string) *coingecko {
+	c.platform = platform
+	return c
+}
+
+func (c *coingecko) request(ctx context.Context, url string) ([]byte, error) {
+	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET).SetCacheIf(func(body []byte) bool {
+		return c.checkError(body) == nil
+	})
+	return c.requester.Do(ctx, net)
 }
 
 func (c *coingecko) check() bool {
 	return c.url != ""
 }
 
-// GetMarketInfoForCoin /asset_platforms
 func (c *coingecko) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
+	return c.GetMarketInfoForCoinCtx(context.Background())
+}
+
+// GetMarketInfoForCoinCtx /asset_platforms
+func (c *coingecko) GetMarketInfoForCoinCtx(ctx context.Context) ([]*types.MarketInfo, error) {
 	if !c.check() {
-		return nil, fmt.Errorf("config mismatched for %s", c.source)
+		return nil, c.misconfigured()
 	}
 
 	url := c.url + "asset_platforms"
-	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET)
-
-	resp, err := net.Request()
+	resp, err := c.request(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = c.checkError(resp); err != nil {
+		return nil, err
+	}
+
 	var cgMarket []*types.CoinGeckoMarket
 	err = json.Unmarshal(resp, &cgMarket)
 	if err != nil {
@@ -69,20 +90,28 @@ func (c *coingecko) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
 }
 
 func (c *coingecko) GetSourceCode(contract string) ([]*types.EtherSourceCode, error) {
-	return nil, fmt.Errorf("unSupport for CoinGecko")
+	return c.GetSourceCodeCtx(context.Background(), contract)
+}
+
+func (c *coingecko) GetSourceCodeCtx(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
+	return nil, fmt.Errorf("%w for CoinGecko", datasource.ErrUnsupported)
 }
 
-func (c *coingecko) getMarketId() error {
+func (c *coingecko) getMarketId(ctx context.Context) error {
 	c.market.Lock.Lock()
 	defer c.market.Lock.Unlock()
 
 	if time.Now().Unix()-c.market.LastUpdatedAt.Unix() > 86400 || len(c.market.Market[string(types.CoinGecko)]) == 0 {
 		// force update
-		markInfo, err := c.GetMarketInfoForCoin()
+		markInfo, err := c.GetMarketInfoForCoinCtx(ctx)
 		if err != nil {
 			return err
 		}
 
+		if c.market.Market == nil {
+			c.market.Market = make(map[string]map[string]*types.MarketInfo)
+		}
+
 		if c.market.Market[string(types.CoinGecko)] == nil {
 			c.market.Market[string(types.CoinGecko)] = make(map[string]*types.MarketInfo)
 		}
@@ -90,29 +119,59 @@ func (c *coingecko) getMarketId() error {
 		for _, coin := range markInfo {
 			c.market.Market[string(types.CoinGecko)][strings.ToLower(coin.Name)] = coin
 		}
+		c.market.LastUpdatedAt = time.Now()
 	}
 
 	return nil
 }
 
-// GetTokenInfo /coins/binance-smart-chain/contract/0xb0d502e938ed5f4df2e681fe6e419ff29631d62b
+// platformID resolves the asset platform of the source: the platform set, the
+// chain registry entry named like the source, then the asset_platforms short names.
+func (c *coingecko) platformID(ctx context.Context) (string, error) {
+	if c.platform != "" {
+		return c.platform, nil
+	}
+
+	if chain, ok := types.ChainByName(c.source); ok && chain.CoinGeckoPlatform != "" {
+		return chain.CoinGeckoPlatform, nil
+	}
+
+	if err := c.getMarketId(ctx); err != nil {
+		return "", err
+	}
+
+	c.market.Lock.RLock()
+	defer c.market.Lock.RUnlock()
+
+	market, ok := c.market.Market[string(types.CoinGecko)][strings.ToLower(c.source)]
+	if !ok {
+		return "", fmt.Errorf("%w: market ID not exist for %s", datasource.ErrMisconfigured, c.source)
+	}
+	return market.ID, nil
+}
+
 func (c *coingecko) GetTokenInfo(contract string) (*types.TokenInfo, error) {
+	return c.GetTokenInfoCtx(context.Background(), contract)
+}
+
+// GetTokenInfoCtx /coins/binance-smart-chain/contract/0xb0d502e938ed5f4df2e681fe6e419ff29631d62b
+func (c *coingecko) GetTokenInfoCtx(ctx context.Context, contract string) (*types.TokenInfo, error) {
 	if !c.check() {
-		return nil, fmt.Errorf("config mismatched for %s", c.source)
+		return nil, c.misconfigured()
 	}
 
-	if err := c.getMarketId(); err != nil {
+	platform, err := c.platformID(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	if _, ok := c.market.Market[string(types.CoinGecko)][c.source]; !ok {
-		return nil, fmt.Errorf("martket ID not exist")
+	url := c.url + "coins/" + platform + "/contract/" + strings.ToLower(contract)
+	resp, err := c.request(ctx, url)
+	if err != nil {
+		return nil, err
 	}
 
-	url := c.url + "coins/" + c.market.Market[string(types.CoinGecko)][c.source].ID + "/contract/" + strings.ToLower(contract)
-	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET)
-	resp, err := net.Request()
-	if err != nil {
+	if err = c.checkError(resp); err != nil {
 		return nil, err
 	}
 
@@ -144,9 +203,17 @@ func (c *coingecko) GetTokenInfo(contract string) (*types.TokenInfo, error) {
 }
 
 func (c *coingecko) GetABIData(contact string) (string, error) {
-	return "", fmt.Errorf("unSupport for CoinGecko")
+	return c.GetABIDataCtx(context.Background(), contact)
+}
+
+func (c *coingecko) GetABIDataCtx(ctx context.Context, contact string) (string, error) {
+	return "", fmt.Errorf("%w for CoinGecko", datasource.ErrUnsupported)
 }
 
 func (c *coingecko) IsVerifyCode(contact string) (bool, error) {
-	return false, fmt.Errorf("unSupport for CoinGecko")
+	return c.IsVerifyCodeCtx(context.Background(), contact)
+}
+
+func (c *coingecko) IsVerifyCodeCtx(ctx context.Context, contact string) (bool, error) {
+	return false, fmt.Errorf("%w for CoinGecko", datasource.ErrUnsupported)
 }
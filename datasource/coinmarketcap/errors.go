@@ -0,0 +1,46 @@
+package coinmarketcap
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strconv"
+)
+
+// error codes: https://coinmarketcap.com/api/documentation/v1/#section/Errors-and-Rate-Limits
+func (c *cmc) serviceError(status types.CmcStatus) error {
+	var sentinel error
+	switch status.ErrorCode {
+	case 1001, 1002, 1003, 1004, 1005, 1006, 1007:
+		sentinel = datasource.ErrInvalidAPIKey
+	case 1008, 1009, 1010, 1011:
+		sentinel = datasource.ErrRateLimited
+	}
+
+	return &datasource.ServiceError{
+		Source:  string(types.CoinMarketCap),
+		Code:    strconv.Itoa(status.ErrorCode),
+		Message: status.ErrorMessage,
+		Err:     sentinel,
+	}
+}
+
+// isRateLimited reports error_code 1008, the per minute rate limit of the api key plan.
+func isRateLimited(body []byte) bool {
+	res := &types.CmcResult{}
+	if err := json.Unmarshal(body, res); err != nil {
+		return false
+	}
+	return res.Status.ErrorCode == 1008
+}
+
+// isOK reports an answer without error_code, the only kind worth caching.
+func isOK(body []byte) bool {
+	res := &types.CmcResult{}
+	return json.Unmarshal(body, res) == nil && res.Status.ErrorCode == 0
+}
+
+func (c *cmc) misconfigured() error {
+	return fmt.Errorf("%w for %s", datasource.ErrMisconfigured, c.source)
+}
@@ -1,52 +1,69 @@
 package coinmarketcap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/ThreeAndTwo/chainscan-api/datasource"
 	"github.com/ThreeAndTwo/chainscan-api/types"
 	"github.com/imroc/req"
 	"github.com/mitchellh/mapstructure"
-	"golang.org/x/time/rate"
 	"strings"
 )
 
 // api document: https://pro-api.coinmarketcap.com/v2/cryptocurrency/info
 
 type cmc struct {
-	source      string
-	url         string
-	apiKey      string
-	rateLimiter *rate.Limiter
-	market      *types.MarketMap
+	source    string
+	url       string
+	apiKey    string
+	requester *datasource.Requester
+	market    *types.MarketMap
+	platform  string
 }
 
-func NewCmc(source, url, apiKey string, rate *rate.Limiter, market *types.MarketMap) *cmc {
+func NewCmc(source, url, apiKey string, requester *datasource.Requester, market *types.MarketMap) *cmc {
 	if url == "" {
 		url = "https://pro-api.coinmarketcap.com"
 	}
-	return &cmc{source: source, url: url, apiKey: apiKey, rateLimiter: rate, market: market}
+	return &cmc{source: source, url: url, apiKey: apiKey, requester: requester, market: market}
+}
+
+// SetPlatform sets the platform slug, e.g. "bnb", GetTokenInfo prefers when an
+// address is listed on several platforms, instead of deriving it from the source name.
+func (c *cmc) SetPlatform(platform string) *cmc {
+	c.platform = platform
+	return c
+}
+
+func (c *cmc) request(ctx context.Context, url string) ([]byte, error) {
+	header := make(map[string]string)
+	header["X-CMC_PRO_API_KEY"] = c.apiKey
+	header["Accept"] = "application/json"
+	reqHeader, _ := datasource.InitHeader(header)
+
+	net := datasource.NewNet(url, reqHeader, req.Param{}, datasource.GET).SetRetryIf(isRateLimited).SetCacheIf(isOK)
+	return c.requester.Do(ctx, net)
 }
 
 func (c *cmc) check() bool {
 	return c.url != "" && c.apiKey != ""
 }
 
-// GetMarketInfoForCoin /v1/cryptocurrency/map
 func (c *cmc) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
+	return c.GetMarketInfoForCoinCtx(context.Background())
+}
+
+// GetMarketInfoForCoinCtx /v1/cryptocurrency/map
+func (c *cmc) GetMarketInfoForCoinCtx(ctx context.Context) ([]*types.MarketInfo, error) {
 
 	if !c.check() {
-		return nil, fmt.Errorf("config mismatched for %s", c.source)
+		return nil, c.misconfigured()
 	}
 
-	header := make(map[string]string)
-	header["X-CMC_PRO_API_KEY"] = c.apiKey
-	header["Accept"] = "application/json"
-	reqHeader, _ := datasource.InitHeader(header)
 	url := c.url + "/v1/cryptocurrency/map"
 
-	net := datasource.NewNet(url, reqHeader, req.Param{}, datasource.GET)
-	resp, err := net.Request()
+	resp, err := c.request(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +74,10 @@ func (c *cmc) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
 		return nil, err
 	}
 
+	if res.Status.ErrorCode != 0 {
+		return nil, c.serviceError(res.Status)
+	}
+
 	var marketInfo []*types.MarketInfo
 	for _, _market := range res.Data.([]interface{}) {
 		data := &types.MarketInfo{}
@@ -70,22 +91,24 @@ func (c *cmc) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
 }
 
 func (c *cmc) GetSourceCode(contract string) ([]*types.EtherSourceCode, error) {
-	return nil, fmt.Errorf("unSupport for CoinMarketCap")
+	return c.GetSourceCodeCtx(context.Background(), contract)
+}
+
+func (c *cmc) GetSourceCodeCtx(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
+	return nil, fmt.Errorf("%w for CoinMarketCap", datasource.ErrUnsupported)
 }
 
 func (c *cmc) GetTokenInfo(contract string) (*types.TokenInfo, error) {
+	return c.GetTokenInfoCtx(context.Background(), contract)
+}
+
+func (c *cmc) GetTokenInfoCtx(ctx context.Context, contract string) (*types.TokenInfo, error) {
 	if !c.check() {
-		return nil, fmt.Errorf("config mismatched for %s", c.source)
+		return nil, c.misconfigured()
 	}
 
-	header := make(map[string]string)
-	header["X-CMC_PRO_API_KEY"] = c.apiKey
-	header["Accept"] = "application/json"
-	reqHeader, _ := datasource.InitHeader(header)
 	url := c.url + "/v2/cryptocurrency/info?address=" + strings.ToLower(contract)
-	net := datasource.NewNet(url, reqHeader, req.Param{}, datasource.GET)
-
-	resp, err := net.Request()
+	resp, err := c.request(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +120,7 @@ func (c *cmc) GetTokenInfo(contract string) (*types.TokenInfo, error) {
 	}
 
 	if res.Status.ErrorCode != 0 {
-		return nil, fmt.Errorf("request service error, %s", resp)
+		return nil, c.serviceError(res.Status)
 	}
 
 	_tokenInfo := make(map[string]types.CmcTokenInfo)
@@ -111,6 +134,18 @@ func (c *cmc) GetTokenInfo(contract string) (*types.TokenInfo, error) {
 		key = k
 	}
 
+	if platform := c.platformSlug(); platform != "" {
+		for k, info := range _tokenInfo {
+			if strings.EqualFold(info.Platform.Slug, platform) {
+				key = k
+			}
+		}
+	}
+
+	if key == "" {
+		return nil, fmt.Errorf("%w: %s on CoinMarketCap", datasource.ErrNotFound, contract)
+	}
+
 	tokenInfo := &types.TokenInfo{
 		Name:        _tokenInfo[key].Name,
 		Symbol:      _tokenInfo[key].Symbol,
@@ -150,9 +185,29 @@ func (c *cmc) GetTokenInfo(contract string) (*types.TokenInfo, error) {
 }
 
 func (c *cmc) GetABIData(contact string) (string, error) {
-	return "", fmt.Errorf("unSupport for CoinMarketCap")
+	return c.GetABIDataCtx(context.Background(), contact)
+}
+
+func (c *cmc) GetABIDataCtx(ctx context.Context, contact string) (string, error) {
+	return "", fmt.Errorf("%w for CoinMarketCap", datasource.ErrUnsupported)
 }
 
 func (c *cmc) IsVerifyCode(contact string) (bool, error) {
-	return false, fmt.Errorf("unSupport for CoinMarketCap")
+	return c.IsVerifyCodeCtx(context.Background(), contact)
+}
+
+func (c *cmc) IsVerifyCodeCtx(ctx context.Context, contact string) (bool, error) {
+	return false, fmt.Errorf("%w for CoinMarketCap", datasource.ErrUnsupported)
+}
+
+// platformSlug is the platform set, or that of the chain registry entry named like the source.
+func (c *cmc) platformSlug() string {
+	if c.platform != "" {
+		return c.platform
+	}
+
+	if chain, ok := types.ChainByName(c.source); ok {
+		return chain.CMCPlatform
+	}
+	return ""
 }
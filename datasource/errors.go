@@ -0,0 +1,34 @@
+package datasource
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by every source, match them with errors.Is.
+// ErrUnsupported and ErrMisconfigured keep the wording callers used to match on.
+var (
+	ErrUnsupported   = errors.New("unSupport")
+	ErrNotVerified   = errors.New("contract source code not verified")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrNotFound      = errors.New("not found")
+	ErrMisconfigured = errors.New("config mismatched")
+)
+
+// ServiceError is an error answered by a remote service. Err is the sentinel
+// the answer was mapped to, it is nil when the answer matched none of them.
+type ServiceError struct {
+	Source  string
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("request service error for %s, code: %s, message: %s", e.Source, e.Code, e.Message)
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,62 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
+
+// GetParsedABI is GetABIDataCtx parsed into a types.ABI, following proxies when proxy resolution is on.
+func (e *ether) GetParsedABI(ctx context.Context, contract string) (*types.ABI, error) {
+	abiJSON, err := e.GetABIDataCtx(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Parse(abiJSON)
+}
+
+// DecodeInput decodes the input of a transaction sent to contract, against the
+// ABI of the implementation when contract is a proxy. Inputs to unverified
+// contracts are decoded through the signature database when one is set.
+func (e *ether) DecodeInput(ctx context.Context, contract, input string) (*types.DecodedCall, error) {
+	abiJSON, err := e.resolvedABI(ctx, contract)
+	if errors.Is(err, datasource.ErrNotVerified) && e.signatures != nil {
+		return e.signatures.DecodeCall(input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := abi.Parse(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	return abi.DecodeCall(parsed, input)
+}
+
+// DecodeConstructorArguments decodes the arguments contract was deployed with
+// against the constructor of its own, unresolved ABI.
+func (e *ether) DecodeConstructorArguments(ctx context.Context, contract string) ([]types.DecodedValue, error) {
+	codes, err := e.getSourceCode(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("%w: source code of %s", datasource.ErrNotFound, contract)
+	}
+
+	// unverified contracts carry a message instead of an ABI
+	if classify(codes[0].ABI) == datasource.ErrNotVerified {
+		return nil, fmt.Errorf("%w: %s", datasource.ErrNotVerified, contract)
+	}
+
+	parsed, err := abi.Parse(codes[0].ABI)
+	if err != nil {
+		return nil, fmt.Errorf("abi of %s: %w", contract, err)
+	}
+	return abi.DecodeConstructor(parsed, codes[0].ConstructorArguments)
+}
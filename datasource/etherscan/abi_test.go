@@ -0,0 +1,101 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecodeInput(t *testing.T) {
+	const implABI = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}]`
+
+	e := newTestEther(t, func(query url.Values) string {
+		switch strings.ToLower(query.Get("address")) {
+		case "0xproxy":
+			return sourceCodeAnswer(`[{"type":"fallback"}]`, "1", "0xImpl")
+		case "0ximpl":
+			return sourceCodeAnswer(implABI, "0", "")
+		}
+		return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+	})
+
+	input := "0xa9059cbb" +
+		"0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	call, err := e.DecodeInput(context.Background(), "0xProxy", input)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got := fmt.Sprintf("%s %s=%v %s=%v", call.Signature, call.Args[0].Name, call.Args[0].Value, call.Args[1].Name, call.Args[1].Value)
+	if got != "transfer(address,uint256) to=0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed value=1000" {
+		t.Fatalf("unexpected call: %s", got)
+	}
+
+	if _, err = e.DecodeInput(context.Background(), "0xProxy", "0xdeadbeef"); err == nil {
+		t.Fatal("expected an unknown selector to fail")
+	}
+}
+
+func TestDecodeConstructorArguments(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		switch strings.ToLower(query.Get("address")) {
+		case "0xunverified":
+			return sourceCodeAnswer("Contract source code not verified", "0", "")
+		case "0xunsupported":
+			return sourceCodeAnswer(`[{"type":"constructor","inputs":[{"name":"rate","type":"fixed128x18"}]}]`, "0", "")
+		}
+
+		record := mustMarshal(t, []map[string]string{{
+			"ABI":                  `[{"type":"constructor","inputs":[{"name":"owner","type":"address"},{"name":"supply","type":"uint256"}]}]`,
+			"ContractName":         "Token",
+			"ConstructorArguments": "0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed00000000000000000000000000000000000000000000000000000000000f4240",
+		}})
+		return `{"status":"1","message":"OK","result":` + record + `}`
+	})
+
+	args, err := e.DecodeConstructorArguments(context.Background(), "0xToken")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got := fmt.Sprintf("%s=%v %s=%v", args[0].Name, args[0].Value, args[1].Name, args[1].Value)
+	if got != "owner=0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed supply=1000000" {
+		t.Fatalf("unexpected args: %s", got)
+	}
+
+	if _, err = e.DecodeConstructorArguments(context.Background(), "0xUnverified"); !errors.Is(err, datasource.ErrNotVerified) {
+		t.Fatalf("expected ErrNotVerified, got: %v", err)
+	}
+	if _, err = e.DecodeConstructorArguments(context.Background(), "0xUnsupported"); err == nil || errors.Is(err, datasource.ErrNotVerified) {
+		t.Fatalf("expected the parse error, got: %v", err)
+	}
+}
+
+func TestSignatureDBFallback(t *testing.T) {
+	const tokenABI = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}]`
+
+	e := newTestEther(t, func(query url.Values) string {
+		if strings.ToLower(query.Get("address")) == "0xtoken" {
+			return `{"status":"1","message":"OK","result":` + mustMarshal(t, tokenABI) + `}`
+		}
+		return sourceCodeAnswer("Contract source code not verified", "0", "")
+	}).SetSignatureDB(abi.NewSignatureDB())
+
+	if _, err := e.GetABIData("0xToken"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	input := "0xa9059cbb" +
+		"0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	call, err := e.DecodeInput(context.Background(), "0xUnverified", input)
+	if err != nil || call.Signature != "transfer(address,uint256)" {
+		t.Fatalf("unexpected call: %+v, %v", call, err)
+	}
+}
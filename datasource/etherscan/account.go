@@ -0,0 +1,148 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// api document: https://docs.etherscan.io/api-endpoints/accounts
+
+// MaxBalanceAddresses is the number of addresses balancemulti accepts per call.
+const MaxBalanceAddresses = 20
+
+type Sort string
+
+const (
+	Asc  Sort = "asc"
+	Desc Sort = "desc"
+)
+
+// TxQuery narrows transaction lists, zero values are left to etherscan's defaults.
+// Etherscan returns at most 10000 records for a block range, whatever the paging.
+type TxQuery struct {
+	StartBlock uint64
+	EndBlock   uint64
+	Page       int
+	Offset     int
+	Sort       Sort
+}
+
+func (q *TxQuery) values() url.Values {
+	params := url.Values{}
+	if q == nil {
+		return params
+	}
+
+	if q.StartBlock != 0 {
+		params.Set("startblock", strconv.FormatUint(q.StartBlock, 10))
+	}
+	if q.EndBlock != 0 {
+		params.Set("endblock", strconv.FormatUint(q.EndBlock, 10))
+	}
+	if q.Page != 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Offset != 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Sort != "" {
+		params.Set("sort", string(q.Sort))
+	}
+	return params
+}
+
+// GetBalance returns the wei balance of address at tag, "latest" when tag is empty.
+func (e *ether) GetBalance(ctx context.Context, address, tag string) (*big.Int, error) {
+	var balance string
+	if err := e.call(ctx, "account", "balance", url.Values{"address": {address}, "tag": {blockTag(tag)}}, &balance); err != nil {
+		return nil, err
+	}
+	return parseBig(balance)
+}
+
+// GetBalances returns the wei balances of up to MaxBalanceAddresses addresses at tag.
+func (e *ether) GetBalances(ctx context.Context, addresses []string, tag string) ([]*types.EtherBalance, error) {
+	if len(addresses) > MaxBalanceAddresses {
+		return nil, fmt.Errorf("balancemulti accepts at most %d addresses, got %d", MaxBalanceAddresses, len(addresses))
+	}
+
+	params := url.Values{"address": {strings.Join(addresses, ",")}, "tag": {blockTag(tag)}}
+	var balances []*types.EtherBalance
+	err := e.call(ctx, "account", "balancemulti", params, &balances)
+	return balances, err
+}
+
+func (e *ether) GetTxList(ctx context.Context, address string, query *TxQuery) ([]*types.EtherTx, error) {
+	params := query.values()
+	params.Set("address", address)
+	return e.txList(ctx, "txlist", params)
+}
+
+func (e *ether) GetInternalTxList(ctx context.Context, address string, query *TxQuery) ([]*types.EtherTx, error) {
+	params := query.values()
+	params.Set("address", address)
+	return e.txList(ctx, "txlistinternal", params)
+}
+
+func (e *ether) GetInternalTxsByHash(ctx context.Context, hash string) ([]*types.EtherTx, error) {
+	return e.txList(ctx, "txlistinternal", url.Values{"txhash": {hash}})
+}
+
+// GetTokenTxList lists ERC20 transfers of address, contract, or both when both are set.
+func (e *ether) GetTokenTxList(ctx context.Context, address, contract string, query *TxQuery) ([]*types.EtherTx, error) {
+	return e.txList(ctx, "tokentx", tokenTxParams(address, contract, query))
+}
+
+// GetNFTTxList lists ERC721 transfers of address, contract, or both when both are set.
+func (e *ether) GetNFTTxList(ctx context.Context, address, contract string, query *TxQuery) ([]*types.EtherTx, error) {
+	return e.txList(ctx, "tokennfttx", tokenTxParams(address, contract, query))
+}
+
+// GetERC1155TxList lists ERC1155 transfers of address, contract, or both when both are set.
+func (e *ether) GetERC1155TxList(ctx context.Context, address, contract string, query *TxQuery) ([]*types.EtherTx, error) {
+	return e.txList(ctx, "token1155tx", tokenTxParams(address, contract, query))
+}
+
+// GetMinedBlocks lists blocks validated by address, blockType is "blocks" or "uncles".
+func (e *ether) GetMinedBlocks(ctx context.Context, address, blockType string, page, offset int) ([]*types.EtherMinedBlock, error) {
+	if blockType == "" {
+		blockType = "blocks"
+	}
+
+	params := (&TxQuery{Page: page, Offset: offset}).values()
+	params.Set("address", address)
+	params.Set("blocktype", blockType)
+
+	var blocks []*types.EtherMinedBlock
+	err := e.call(ctx, "account", "getminedblocks", params, &blocks)
+	return blocks, err
+}
+
+func (e *ether) txList(ctx context.Context, action string, params url.Values) ([]*types.EtherTx, error) {
+	var txs []*types.EtherTx
+	err := e.call(ctx, "account", action, params, &txs)
+	return txs, err
+}
+
+func tokenTxParams(address, contract string, query *TxQuery) url.Values {
+	params := query.values()
+	if address != "" {
+		params.Set("address", address)
+	}
+	if contract != "" {
+		params.Set("contractaddress", contract)
+	}
+	return params
+}
+
+func blockTag(tag string) string {
+	if tag == "" {
+		return "latest"
+	}
+	return tag
+}
@@ -0,0 +1,63 @@
+package etherscan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newTestEther serves every etherscan call with answer, keyed on the query it receives.
+func newTestEther(t *testing.T, answer func(query url.Values) string) *ether {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(answer(r.URL.Query())))
+	}))
+	t.Cleanup(srv.Close)
+	return NewEther("etherscan", srv.URL, "key", nil)
+}
+
+func TestGetTxList(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		if query.Get("action") != "txlist" || query.Get("startblock") != "100" || query.Get("sort") != "asc" {
+			t.Errorf("unexpected query: %s", query.Encode())
+		}
+		return `{"status":"1","message":"OK","result":[{"blockNumber":"14923678","timeStamp":"1654646411","hash":"0xc5","nonce":"0","from":"0xa","to":"0xb","value":"1000000000000000000000","gas":"21000","gasPrice":"64654351921","isError":"0","txreceipt_status":"1","input":"0x","confirmations":"100"}]}`
+	})
+
+	txs, err := e.GetTxList(context.Background(), "0xa", &TxQuery{StartBlock: 100, Sort: Asc})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(txs) != 1 || txs[0].BlockNumber != 14923678 || txs[0].Value.String() != "1000000000000000000000" ||
+		txs[0].TimeStamp.Unix() != 1654646411 || txs[0].IsError {
+		t.Fatalf("unexpected txs: %+v", txs[0])
+	}
+}
+
+func TestGetTxListEmpty(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		return `{"status":"0","message":"No transactions found","result":[]}`
+	})
+
+	txs, err := e.GetTokenTxList(context.Background(), "0xa", "", nil)
+	if err != nil || len(txs) != 0 {
+		t.Fatalf("expected an empty list, got: %v, %v", txs, err)
+	}
+}
+
+func TestGetBalances(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		return `{"status":"1","message":"OK","result":[{"account":"0xa","balance":"40891626854930000000000"},{"account":"0xb","balance":"0"}]}`
+	})
+
+	balances, err := e.GetBalances(context.Background(), []string{"0xa", "0xb"}, "")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(balances) != 2 || balances[0].Balance.String() != "40891626854930000000000" || balances[1].Balance.Sign() != 0 {
+		t.Fatalf("unexpected balances: %+v", balances)
+	}
+}
@@ -0,0 +1,82 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Closest picks the block GetBlockNumberByTime returns when no block was mined
+// exactly at the time asked for.
+type Closest string
+
+const (
+	Before Closest = "before"
+	After  Closest = "after"
+)
+
+// BlockRange is an inclusive range of blocks, as resolved by GetBlockRange.
+type BlockRange struct {
+	StartBlock uint64
+	EndBlock   uint64
+}
+
+// TxQuery returns a query over the transactions of r, sorted oldest first.
+func (r BlockRange) TxQuery() *TxQuery {
+	return &TxQuery{StartBlock: r.StartBlock, EndBlock: r.EndBlock, Sort: Asc}
+}
+
+// LogQuery returns a query over the logs of address within r.
+func (r BlockRange) LogQuery(address string) *LogQuery {
+	return &LogQuery{FromBlock: r.StartBlock, ToBlock: r.EndBlock, Address: address}
+}
+
+func (e *ether) GetBlockNumberByTime(ctx context.Context, at time.Time, closest Closest) (uint64, error) {
+	params := url.Values{"timestamp": {strconv.FormatInt(at.Unix(), 10)}, "closest": {string(closest)}}
+	var number uint64
+	err := e.call(ctx, "block", "getblocknobytime", params, &number)
+	return number, err
+}
+
+// GetBlockRange resolves [from, to] to the blocks mined within it, from the first
+// block at or after from to the last block at or before to.
+func (e *ether) GetBlockRange(ctx context.Context, from, to time.Time) (BlockRange, error) {
+	if to.Before(from) {
+		return BlockRange{}, fmt.Errorf("invalid time range, %s is before %s", to, from)
+	}
+
+	start, err := e.GetBlockNumberByTime(ctx, from, After)
+	if err != nil {
+		return BlockRange{}, err
+	}
+
+	end, err := e.GetBlockNumberByTime(ctx, to, Before)
+	if err != nil {
+		return BlockRange{}, err
+	}
+
+	if end < start {
+		return BlockRange{}, fmt.Errorf("no block was mined between %s and %s", from, to)
+	}
+	return BlockRange{StartBlock: start, EndBlock: end}, nil
+}
+
+func (e *ether) GetBlockReward(ctx context.Context, number uint64) (*types.EtherBlockReward, error) {
+	reward := &types.EtherBlockReward{}
+	if err := e.call(ctx, "block", "getblockreward", url.Values{"blockno": {strconv.FormatUint(number, 10)}}, reward); err != nil {
+		return nil, err
+	}
+	return reward, nil
+}
+
+// GetBlockCountdown estimates the time until block number is mined, it must be a future block.
+func (e *ether) GetBlockCountdown(ctx context.Context, number uint64) (*types.EtherBlockCountdown, error) {
+	countdown := &types.EtherBlockCountdown{}
+	if err := e.call(ctx, "block", "getblockcountdown", url.Values{"blockno": {strconv.FormatUint(number, 10)}}, countdown); err != nil {
+		return nil, err
+	}
+	return countdown, nil
+}
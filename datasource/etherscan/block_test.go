@@ -0,0 +1,57 @@
+package etherscan
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetBlockRange(t *testing.T) {
+	from := time.Unix(1578638524, 0)
+	to := from.Add(time.Hour)
+
+	e := newTestEther(t, func(query url.Values) string {
+		if query.Get("action") != "getblocknobytime" {
+			return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+		}
+
+		switch query.Get("timestamp") + " " + query.Get("closest") {
+		case "1578638524 after":
+			return `{"status":"1","message":"OK","result":"9251483"}`
+		case "1578642124 before":
+			return `{"status":"1","message":"OK","result":"9251755"}`
+		}
+		t.Errorf("unexpected query: %s", query.Encode())
+		return `{"status":"0","message":"NOTOK","result":"Error! No closest block found"}`
+	})
+
+	blocks, err := e.GetBlockRange(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	query := blocks.TxQuery()
+	if blocks != (BlockRange{StartBlock: 9251483, EndBlock: 9251755}) || query.StartBlock != 9251483 || query.EndBlock != 9251755 {
+		t.Fatalf("unexpected range: %+v", blocks)
+	}
+
+	if _, err = e.GetBlockRange(context.Background(), to, from); err == nil {
+		t.Fatal("expected a reversed range to fail")
+	}
+}
+
+func TestGetBlockReward(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		return `{"status":"1","message":"OK","result":{"blockNumber":"2165403","timeStamp":"1472533979","blockMiner":"0x13a06d3dfe21e0db5c016c03ea7d2509f7f8d1e3","blockReward":"5314181600000000000","uncles":[{"miner":"0xbcdfc35b86bedf72f0cda046a3c16829a2ef41d1","unclePosition":"0","blockreward":"3750000000000000000"}],"uncleInclusionReward":"312500000000000000"}}`
+	})
+
+	reward, err := e.GetBlockReward(context.Background(), 2165403)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if reward.BlockNumber != 2165403 || reward.BlockReward.String() != "5314181600000000000" ||
+		len(reward.Uncles) != 1 || reward.Uncles[0].BlockReward.String() != "3750000000000000000" {
+		t.Fatalf("unexpected reward: %+v", reward)
+	}
+}
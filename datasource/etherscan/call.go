@@ -0,0 +1,190 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"github.com/imroc/req"
+	"github.com/mitchellh/mapstructure"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// endpoint builds the query for module and action, params may be nil.
+func (e *ether) endpoint(module, action string, params url.Values) string {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	query.Set("module", module)
+	query.Set("action", action)
+	query.Set("apikey", e.apiKey)
+	if e.chainID != 0 {
+		query.Set("chainid", strconv.FormatUint(e.chainID, 10))
+	}
+	return e.url + query.Encode()
+}
+
+// call requests module and action then decodes the result into out, which may be nil.
+// An empty list answered as "No transactions found" leaves out untouched.
+func (e *ether) call(ctx context.Context, module, action string, params url.Values, out interface{}) error {
+	if !e.check() {
+		return e.misconfigured()
+	}
+
+	net := datasource.NewNet(e.endpoint(module, action, params), req.Header{}, req.Param{}, datasource.GET)
+	res, err := e.result(ctx, net.SetCacheIf(cacheIf(module, action)))
+	if err != nil {
+		return err
+	}
+	return e.decodeResult(res, out)
+}
+
+// post is call sending params as a form, for payloads too large for a query string.
+func (e *ether) post(ctx context.Context, module, action string, params url.Values, out interface{}) error {
+	if !e.check() {
+		return e.misconfigured()
+	}
+
+	form := req.Param{"module": module, "action": action, "apikey": e.apiKey}
+	for k, v := range params {
+		form[k] = v[0]
+	}
+
+	// the v2 api reads chainid from the query string of posts too
+	target := e.url
+	if e.chainID != 0 {
+		target += url.Values{"chainid": {strconv.FormatUint(e.chainID, 10)}}.Encode()
+	}
+
+	net := datasource.NewNet(target, req.Header{}, form, datasource.POST)
+	res, err := e.result(ctx, net)
+	if err != nil {
+		return err
+	}
+	return e.decodeResult(res, out)
+}
+
+// cacheIf returns the check letting a Requester cache answers to module and action,
+// nil for answers that change from one call to the next.
+func cacheIf(module, action string) func([]byte) bool {
+	switch {
+	case module == "proxy", module == "gastracker", action == "getblockcountdown",
+		action == "checkverifystatus", action == "checkproxyverification":
+		return nil
+	default:
+		return isOK
+	}
+}
+
+// result sends net and parses the answer without looking at its status.
+func (e *ether) result(ctx context.Context, net *datasource.Net) (*types.EtherResult, error) {
+	resp, err := e.requester.Do(ctx, net.SetRetryIf(isRateLimited))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.EtherResult{}
+	if err = json.Unmarshal(resp, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (e *ether) decodeResult(res *types.EtherResult, out interface{}) error {
+	if res.Status != "1" {
+		if list, ok := res.Result.([]interface{}); ok && len(list) == 0 {
+			return nil
+		}
+		return e.serviceError(res)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return decode(res.Result, out)
+}
+
+// decode converts the string encoded numbers, booleans, unix timestamps and dates etherscan
+// answers with into the types of out's fields, matched by json tag.
+func decode(input, out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHook,
+		WeaklyTypedInput: true,
+		TagName:          "json",
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+var (
+	bigIntType = reflect.TypeOf(&big.Int{})
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+func decodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	str, ok := data.(string)
+	if !ok || from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	switch {
+	case to == bigIntType:
+		return parseBig(str)
+	case to == timeType:
+		if str == "" {
+			return time.Time{}, nil
+		}
+		// the stats module answers days rather than timestamps
+		if date, err := time.Parse(dateLayout, str); err == nil {
+			return date, nil
+		}
+		seconds, err := parseBig(str)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(seconds.Int64(), 0).UTC(), nil
+	case isHex(str) && to.Kind() >= reflect.Uint && to.Kind() <= reflect.Uint64:
+		// the logs and proxy modules answer hex quantities, "0x" standing for zero
+		value, err := parseBig(str)
+		if err != nil {
+			return nil, err
+		}
+		return value.Uint64(), nil
+	default:
+		return data, nil
+	}
+}
+
+func isHex(str string) bool {
+	return strings.HasPrefix(str, "0x") || strings.HasPrefix(str, "0X")
+}
+
+// parseBig accepts decimal and 0x prefixed hex numbers, an empty string is zero.
+func parseBig(str string) (*big.Int, error) {
+	str = strings.TrimSpace(str)
+	if str == "" || str == "0x" || str == "0X" {
+		return new(big.Int), nil
+	}
+
+	base := 10
+	if isHex(str) {
+		str, base = str[2:], 16
+	}
+
+	value, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", str)
+	}
+	return value, nil
+}
@@ -0,0 +1,26 @@
+package etherscan
+
+// V2URL is the Etherscan V2 api, one url and api key serving every supported
+// chain, selected by the chainid parameter.
+const V2URL = "https://api.etherscan.io/v2/api"
+
+// SetChainID makes every request of e carry chainID, as the V2 api requires.
+// Zero leaves chainid out, for explorers serving a single chain.
+func (e *ether) SetChainID(chainID uint64) *ether {
+	e.chainID = chainID
+	return e
+}
+
+func (e *ether) ChainID() uint64 {
+	return e.chainID
+}
+
+// OnChain returns a copy of e answering for chainID, sharing its url, api key,
+// rate limiter and caches, for picking the chain per call:
+//
+//	balance, err := e.OnChain(56).GetBalance(ctx, address, "")
+func (e *ether) OnChain(chainID uint64) *ether {
+	c := *e
+	c.chainID = chainID
+	return &c
+}
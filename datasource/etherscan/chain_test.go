@@ -0,0 +1,30 @@
+package etherscan
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestChainID(t *testing.T) {
+	var chains []string
+	e := newTestEther(t, func(query url.Values) string {
+		chains = append(chains, query.Get("chainid"))
+		return `{"status":"1","message":"OK","result":"1"}`
+	}).SetChainID(1)
+
+	ctx := context.Background()
+	if _, err := e.GetBalance(ctx, "0xa", ""); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := e.OnChain(56).GetBalance(ctx, "0xa", ""); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := e.SetChainID(0).GetBalance(ctx, "0xa", ""); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(chains) != 3 || chains[0] != "1" || chains[1] != "56" || chains[2] != "" {
+		t.Fatalf("unexpected chainid params: %q", chains)
+	}
+}
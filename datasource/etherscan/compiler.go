@@ -0,0 +1,178 @@
+package etherscan
+
+import (
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strconv"
+	"strings"
+)
+
+// spdxLicenses maps the LicenseType names etherscan shows to SPDX identifiers.
+var spdxLicenses = map[string]string{
+	"none":         "UNLICENSED",
+	"unlicense":    "Unlicense",
+	"mit":          "MIT",
+	"gnu gplv2":    "GPL-2.0-only",
+	"gnu gplv3":    "GPL-3.0-only",
+	"gnu lgplv2.1": "LGPL-2.1-only",
+	"gnu lgplv3":   "LGPL-3.0-only",
+	"bsd-2-clause": "BSD-2-Clause",
+	"bsd-3-clause": "BSD-3-Clause",
+	"mpl-2.0":      "MPL-2.0",
+	"osl-3.0":      "OSL-3.0",
+	"apache-2.0":   "Apache-2.0",
+	"gnu agplv3":   "AGPL-3.0-only",
+	"bsl 1.1":      "BUSL-1.1",
+}
+
+var evmVersions = []types.EVMVersion{
+	types.EVMHomestead, types.EVMTangerineWhistle, types.EVMSpuriousDragon, types.EVMByzantium,
+	types.EVMConstantinople, types.EVMPetersburg, types.EVMIstanbul, types.EVMBerlin, types.EVMLondon,
+	types.EVMParis, types.EVMShanghai, types.EVMCancun, types.EVMPrague,
+}
+
+type evmDefault struct {
+	since types.Semver
+	evm   types.EVMVersion
+}
+
+// solcDefaults and vyperDefaults list the releases that changed the default EVM
+// version, oldest first.
+var (
+	solcDefaults = []evmDefault{
+		{types.Semver{Minor: 4, Patch: 21}, types.EVMByzantium},
+		{types.Semver{Minor: 5, Patch: 5}, types.EVMPetersburg},
+		{types.Semver{Minor: 5, Patch: 14}, types.EVMIstanbul},
+		{types.Semver{Minor: 8, Patch: 5}, types.EVMBerlin},
+		{types.Semver{Minor: 8, Patch: 7}, types.EVMLondon},
+		{types.Semver{Minor: 8, Patch: 18}, types.EVMParis},
+		{types.Semver{Minor: 8, Patch: 20}, types.EVMShanghai},
+		{types.Semver{Minor: 8, Patch: 25}, types.EVMCancun},
+		{types.Semver{Minor: 8, Patch: 30}, types.EVMPrague},
+	}
+	vyperDefaults = []evmDefault{
+		{types.Semver{Minor: 1}, types.EVMByzantium},
+		{types.Semver{Minor: 2}, types.EVMIstanbul},
+		{types.Semver{Minor: 3}, types.EVMBerlin},
+		{types.Semver{Minor: 3, Patch: 4}, types.EVMLondon},
+		{types.Semver{Minor: 3, Patch: 8}, types.EVMShanghai},
+		{types.Semver{Minor: 4}, types.EVMCancun},
+	}
+)
+
+// ParseCompilerInfo reads the compiler metadata etherscan reports alongside the
+// source code of a verified contract.
+func ParseCompilerInfo(code *types.EtherSourceCode) (*types.CompilerInfo, error) {
+	compiler, version, err := parseCompilerVersion(code.CompilerVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.CompilerInfo{
+		Compiler:  compiler,
+		Version:   version,
+		Nightly:   strings.HasPrefix(version.Prerelease, "nightly"),
+		Optimizer: types.Optimizer{Enabled: code.OptimizationUsed == "1"},
+		License:   spdxLicense(code.LicenseType),
+		Libraries: parseLibraries(code.Library),
+	}
+
+	if code.Runs != "" {
+		if info.Optimizer.Runs, err = strconv.Atoi(strings.TrimSpace(code.Runs)); err != nil {
+			return nil, fmt.Errorf("invalid runs %q: %w", code.Runs, err)
+		}
+	}
+
+	if len(info.Libraries) == 0 {
+		info.Libraries = nil
+	}
+
+	info.EVMVersion = parseEVMVersion(code.EVMVersion)
+	if info.EVMVersion == "" {
+		info.EVMVersion = defaultEVMVersion(compiler, version)
+	}
+	return info, nil
+}
+
+// parseCompilerVersion splits versions like "v0.8.19+commit.7dd6d404",
+// "v0.4.24-nightly.2018.5.16+commit.7f965c86" or "vyper:0.3.7".
+func parseCompilerVersion(compilerVersion string) (string, types.Semver, error) {
+	compiler, raw := "solc", strings.TrimSpace(compilerVersion)
+	if i := strings.Index(raw, ":"); i >= 0 {
+		compiler, raw = strings.ToLower(raw[:i]), raw[i+1:]
+	}
+	raw = strings.TrimPrefix(raw, "v")
+
+	version := types.Semver{}
+	if i := strings.Index(raw, "+"); i >= 0 {
+		version.Commit = strings.TrimPrefix(raw[i+1:], "commit.")
+		raw = raw[:i]
+	}
+	if i := strings.Index(raw, "-"); i >= 0 {
+		version.Prerelease = raw[i+1:]
+		raw = raw[:i]
+	}
+
+	parts := strings.SplitN(raw, ".", 3)
+	if len(parts) != 3 {
+		return "", version, fmt.Errorf("invalid compiler version %q", compilerVersion)
+	}
+
+	// vyper betas carry their prerelease without a dash, as in 0.1.0b17
+	patch := parts[2]
+	if i := strings.IndexFunc(patch, func(r rune) bool { return r < '0' || r > '9' }); i > 0 {
+		if version.Prerelease == "" {
+			version.Prerelease = patch[i:]
+		}
+		patch = patch[:i]
+	}
+
+	numbers := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range []string{parts[0], parts[1], patch} {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return "", version, fmt.Errorf("invalid compiler version %q", compilerVersion)
+		}
+		*numbers[i] = n
+	}
+	return compiler, version, nil
+}
+
+// parseEVMVersion returns "" for etherscan's "Default".
+func parseEVMVersion(evmVersion string) types.EVMVersion {
+	evmVersion = strings.TrimSpace(evmVersion)
+	if evmVersion == "" || strings.EqualFold(evmVersion, "default") {
+		return ""
+	}
+
+	for _, version := range evmVersions {
+		if strings.EqualFold(string(version), evmVersion) {
+			return version
+		}
+	}
+	return types.EVMVersion(strings.ToLower(evmVersion))
+}
+
+func defaultEVMVersion(compiler string, version types.Semver) types.EVMVersion {
+	defaults := solcDefaults
+	if compiler == "vyper" {
+		defaults = vyperDefaults
+	}
+
+	// solc targeted homestead before the evm version became configurable
+	evm := types.EVMHomestead
+	for _, d := range defaults {
+		if version.Less(d.since) {
+			break
+		}
+		evm = d.evm
+	}
+	return evm
+}
+
+func spdxLicense(license string) string {
+	if spdx, ok := spdxLicenses[strings.ToLower(strings.TrimSpace(license))]; ok {
+		return spdx
+	}
+	return strings.TrimSpace(license)
+}
@@ -0,0 +1,47 @@
+package etherscan
+
+import (
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"testing"
+)
+
+func TestParseCompilerInfo(t *testing.T) {
+	info, err := ParseCompilerInfo(&types.EtherSourceCode{
+		CompilerVersion:  "v0.4.24-nightly.2018.5.16+commit.7f965c86",
+		OptimizationUsed: "1",
+		Runs:             "200",
+		EVMVersion:       "Default",
+		LicenseType:      "GNU GPLv3",
+		Library:          "SafeMath:0x0F4d6fc1e2D4E8dCB1A16E2b2Fc7b5f8a0d3B8d1",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if info.Compiler != "solc" || info.Version.String() != "0.4.24-nightly.2018.5.16" || info.Version.Commit != "7f965c86" ||
+		!info.Nightly || info.Optimizer != (types.Optimizer{Enabled: true, Runs: 200}) || info.EVMVersion != types.EVMByzantium ||
+		info.License != "GPL-3.0-only" || info.Libraries["SafeMath"] == "" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	cases := []struct {
+		compiler, evm string
+		want          types.EVMVersion
+	}{
+		{"v0.8.19+commit.7dd6d404", "Default", types.EVMParis},
+		{"v0.8.19+commit.7dd6d404", "London", types.EVMLondon},
+		{"v0.4.11+commit.68ef5810", "Default", types.EVMHomestead},
+		{"vyper:0.3.7", "Default", types.EVMLondon},
+		{"vyper:0.1.0b17", "", types.EVMByzantium},
+	}
+	for _, c := range cases {
+		info, err = ParseCompilerInfo(&types.EtherSourceCode{CompilerVersion: c.compiler, EVMVersion: c.evm})
+		if err != nil || info.EVMVersion != c.want || info.Nightly {
+			t.Errorf("%s %s: got %+v, %v", c.compiler, c.evm, info, err)
+		}
+	}
+
+	if _, err = ParseCompilerInfo(&types.EtherSourceCode{CompilerVersion: "latest"}); err == nil {
+		t.Fatal("expected an invalid compiler version to fail")
+	}
+}
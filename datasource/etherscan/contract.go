@@ -0,0 +1,33 @@
+package etherscan
+
+import (
+	"context"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/url"
+	"strings"
+)
+
+// api document: https://docs.etherscan.io/api-endpoints/contracts
+
+// MaxContractCreationAddresses is the number of addresses getcontractcreation accepts per call.
+const MaxContractCreationAddresses = 5
+
+// GetContractCreation returns the creator and creation tx of contracts, larger
+// batches are split into calls of MaxContractCreationAddresses addresses.
+func (e *ether) GetContractCreation(ctx context.Context, contracts []string) ([]*types.EtherContractCreation, error) {
+	var creations []*types.EtherContractCreation
+	for start := 0; start < len(contracts); start += MaxContractCreationAddresses {
+		end := start + MaxContractCreationAddresses
+		if end > len(contracts) {
+			end = len(contracts)
+		}
+
+		var batch []*types.EtherContractCreation
+		params := url.Values{"contractaddresses": {strings.Join(contracts[start:end], ",")}}
+		if err := e.call(ctx, "contract", "getcontractcreation", params, &batch); err != nil {
+			return nil, err
+		}
+		creations = append(creations, batch...)
+	}
+	return creations, nil
+}
@@ -0,0 +1,37 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetContractCreationBatches(t *testing.T) {
+	var batches []int
+	e := newTestEther(t, func(query url.Values) string {
+		addresses := strings.Split(query.Get("contractaddresses"), ",")
+		batches = append(batches, len(addresses))
+
+		var records []string
+		for _, address := range addresses {
+			records = append(records, fmt.Sprintf(`{"contractAddress":"%s","contractCreator":"0xc","txHash":"0x%s"}`, address, address))
+		}
+		return `{"status":"1","message":"OK","result":[` + strings.Join(records, ",") + `]}`
+	})
+
+	var contracts []string
+	for i := 0; i < 12; i++ {
+		contracts = append(contracts, fmt.Sprintf("0x%d", i))
+	}
+
+	creations, err := e.GetContractCreation(context.Background(), contracts)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(creations) != 12 || fmt.Sprint(batches) != "[5 5 2]" || creations[11].ContractCreator != "0xc" {
+		t.Fatalf("unexpected creations: %d in batches %v", len(creations), batches)
+	}
+}
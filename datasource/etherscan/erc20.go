@@ -0,0 +1,152 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// decimalsSelector is the selector of the erc20 decimals() function.
+const decimalsSelector = "0x313ce567"
+
+// GetTokenSupply returns the current total supply of the erc20 token contract.
+func (e *ether) GetTokenSupply(ctx context.Context, contract string) (*types.TokenAmount, error) {
+	return e.tokenAmount(ctx, contract, "stats", "tokensupply", url.Values{"contractaddress": {contract}})
+}
+
+// GetTokenSupplyAt returns the total supply of contract at block number.
+func (e *ether) GetTokenSupplyAt(ctx context.Context, contract string, number uint64) (*types.TokenAmount, error) {
+	params := url.Values{"contractaddress": {contract}, "blockno": {strconv.FormatUint(number, 10)}}
+	return e.tokenAmount(ctx, contract, "stats", "tokensupplyhistory", params)
+}
+
+// GetTokenBalance returns the balance of address in the erc20 token contract at tag.
+func (e *ether) GetTokenBalance(ctx context.Context, contract, address, tag string) (*types.TokenAmount, error) {
+	params := url.Values{"contractaddress": {contract}, "address": {address}, "tag": {blockTag(tag)}}
+	return e.tokenAmount(ctx, contract, "account", "tokenbalance", params)
+}
+
+// GetTokenBalanceAt returns the balance of address in contract at block number.
+func (e *ether) GetTokenBalanceAt(ctx context.Context, contract, address string, number uint64) (*types.TokenAmount, error) {
+	params := url.Values{"contractaddress": {contract}, "address": {address}, "blockno": {strconv.FormatUint(number, 10)}}
+	return e.tokenAmount(ctx, contract, "account", "tokenbalancehistory", params)
+}
+
+// GetTokenHolders pages through the holders of contract, page starts at 1.
+func (e *ether) GetTokenHolders(ctx context.Context, contract string, page, offset int) ([]*types.EtherTokenHolder, error) {
+	params := url.Values{"contractaddress": {contract}, "page": {strconv.Itoa(page)}, "offset": {strconv.Itoa(offset)}}
+	return e.tokenHolders(ctx, contract, "tokenholderlist", params)
+}
+
+// GetTopHolders returns the count largest holders of contract.
+func (e *ether) GetTopHolders(ctx context.Context, contract string, count int) ([]*types.EtherTokenHolder, error) {
+	params := url.Values{"contractaddress": {contract}, "offset": {strconv.Itoa(count)}}
+	return e.tokenHolders(ctx, contract, "topholders", params)
+}
+
+// TokenDecimals returns the decimals of the erc20 token contract, read once
+// through the proxy module and remembered afterwards.
+func (e *ether) TokenDecimals(ctx context.Context, contract string) (uint8, error) {
+	key := strconv.FormatUint(e.chainID, 10) + ":" + strings.ToLower(contract)
+	if decimals, ok := e.decimals.get(key); ok {
+		return decimals, nil
+	}
+
+	data, err := e.Call(ctx, &CallMsg{To: contract, Data: decimalsSelector}, "")
+	if err != nil {
+		return 0, err
+	}
+
+	// accounts and contracts without decimals() answer the call with no data
+	if data = strings.TrimSpace(data); data == "" || strings.EqualFold(data, "0x") {
+		return 0, fmt.Errorf("%w: no decimals() on %s", datasource.ErrNotFound, contract)
+	}
+
+	value, err := parseBig(data)
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() || value.Uint64() > 255 {
+		return 0, fmt.Errorf("invalid decimals %s of %s", value, contract)
+	}
+
+	decimals := uint8(value.Uint64())
+	e.decimals.set(key, decimals)
+	return decimals, nil
+}
+
+// decimalsCache is shared by the copies OnChain makes, keys carry the chain ID.
+type decimalsCache struct {
+	mu     sync.Mutex
+	values map[string]uint8
+}
+
+func (c *decimalsCache) get(key string) (uint8, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	decimals, ok := c.values[key]
+	return decimals, ok
+}
+
+func (c *decimalsCache) set(key string, decimals uint8) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.values == nil {
+		c.values = make(map[string]uint8)
+	}
+	c.values[key] = decimals
+}
+
+func (e *ether) tokenAmount(ctx context.Context, contract, module, action string, params url.Values) (*types.TokenAmount, error) {
+	var raw string
+	if err := e.call(ctx, module, action, params, &raw); err != nil {
+		return nil, err
+	}
+
+	value, err := parseBig(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals, err := e.TokenDecimals(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	return &types.TokenAmount{Value: value, Decimals: decimals}, nil
+}
+
+func (e *ether) tokenHolders(ctx context.Context, contract, action string, params url.Values) ([]*types.EtherTokenHolder, error) {
+	var raw []struct {
+		Address  string   `json:"TokenHolderAddress"`
+		Quantity *big.Int `json:"TokenHolderQuantity"`
+	}
+	if err := e.call(ctx, "token", action, params, &raw); err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	decimals, err := e.TokenDecimals(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	holders := make([]*types.EtherTokenHolder, 0, len(raw))
+	for _, holder := range raw {
+		holders = append(holders, &types.EtherTokenHolder{
+			Address: holder.Address,
+			Balance: &types.TokenAmount{Value: holder.Quantity, Decimals: decimals},
+		})
+	}
+	return holders, nil
+}
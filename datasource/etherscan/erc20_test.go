@@ -0,0 +1,93 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+	"net/url"
+	"testing"
+)
+
+func TestTokenAmounts(t *testing.T) {
+	decimalsCalls := 0
+	e := newTestEther(t, func(query url.Values) string {
+		switch query.Get("action") {
+		case "eth_call":
+			decimalsCalls++
+			if query.Get("to") == "0xEOA" {
+				return `{"jsonrpc":"2.0","id":1,"result":"0x"}`
+			}
+			if query.Get("to") != "0xUSDC" || query.Get("data") != decimalsSelector {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000006"}`
+		case "tokensupply":
+			return `{"status":"1","message":"OK","result":"21265524714464"}`
+		case "tokenbalancehistory":
+			if query.Get("blockno") != "8000000" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"status":"1","message":"OK","result":"135499"}`
+		case "tokenholderlist":
+			return `{"status":"1","message":"OK","result":[{"TokenHolderAddress":"0x0000000000000000000000000000000000000001","TokenHolderQuantity":"1500000"}]}`
+		case "tokeninfo":
+			return `{"status":"1","message":"OK","result":[{"contractAddress":"0xusdc","tokenName":"USD Coin","symbol":"USDC","divisor":"6","tokenType":"ERC20","totalSupply":"21265524714464"}]}`
+		}
+		return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+	})
+	ctx := context.Background()
+
+	supply, err := e.GetTokenSupply(ctx, "0xUSDC")
+	if err != nil || supply.String() != "21265524.714464" {
+		t.Fatalf("unexpected supply: %v, %v", supply, err)
+	}
+
+	balance, err := e.GetTokenBalanceAt(ctx, "0xUSDC", "0xa", 8000000)
+	if err != nil || balance.String() != "0.135499" {
+		t.Fatalf("unexpected balance: %v, %v", balance, err)
+	}
+
+	holders, err := e.GetTokenHolders(ctx, "0xUSDC", 1, 10)
+	if err != nil || len(holders) != 1 || holders[0].Balance.String() != "1.5" {
+		t.Fatalf("unexpected holders: %+v, %v", holders, err)
+	}
+
+	if decimalsCalls != 1 {
+		t.Fatalf("expected decimals to be read once, got %d calls", decimalsCalls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = e.TokenDecimals(ctx, "0xEOA"); !errors.Is(err, datasource.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound without decimals(), got: %v", err)
+		}
+	}
+	if decimalsCalls != 3 {
+		t.Fatalf("expected missing decimals not to be remembered, got %d calls", decimalsCalls)
+	}
+
+	info, err := e.GetTokenInfo("0xUSDC")
+	if err != nil || info.Symbol != "USDC" || info.TotalSupply != "21265524714464" {
+		t.Fatalf("unexpected token info: %+v, %v", info, err)
+	}
+}
+
+func TestTokenAmountString(t *testing.T) {
+	cases := map[string]*types.TokenAmount{
+		"0.000001": {Value: big.NewInt(1), Decimals: 6},
+		"-12.5":    {Value: big.NewInt(-12500), Decimals: 3},
+		"42":       {Value: big.NewInt(42), Decimals: 0},
+		"1":        {Value: big.NewInt(1000), Decimals: 3},
+	}
+	for want, amount := range cases {
+		if got := amount.String(); got != want {
+			t.Errorf("%s/10^%d: got %s, want %s", amount.Value, amount.Decimals, got, want)
+		}
+	}
+
+	empty := &types.TokenAmount{Decimals: 18}
+	if empty.String() != "0" || empty.Float().Sign() != 0 {
+		t.Fatalf("expected a nil value to be zero, got %s, %s", empty.String(), empty.Float())
+	}
+}
@@ -0,0 +1,63 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strings"
+)
+
+// serviceError maps an etherscan answer whose status is not "1" to a datasource.ServiceError,
+// etherscan puts the reason in result for NOTOK answers and in message otherwise.
+func (e *ether) serviceError(res *types.EtherResult) error {
+	detail := res.Message
+	if reason, ok := res.Result.(string); ok && reason != "" {
+		detail = reason
+	}
+
+	return &datasource.ServiceError{
+		Source:  e.source,
+		Code:    res.Status,
+		Message: detail,
+		Err:     classify(res.Message + " " + detail),
+	}
+}
+
+// isRateLimited reports the "Max rate limit reached" NOTOK answer etherscan sends with HTTP 200.
+func isRateLimited(body []byte) bool {
+	res := &types.EtherResult{}
+	if err := json.Unmarshal(body, res); err != nil || res.Status == "1" {
+		return false
+	}
+
+	reason, _ := res.Result.(string)
+	return classify(res.Message+" "+reason) == datasource.ErrRateLimited
+}
+
+// isOK reports a successful answer, the only kind worth caching.
+func isOK(body []byte) bool {
+	res := &types.EtherResult{}
+	return json.Unmarshal(body, res) == nil && res.Status == "1"
+}
+
+func classify(text string) error {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "rate limit"):
+		return datasource.ErrRateLimited
+	case strings.Contains(text, "api key"), strings.Contains(text, "apikey"):
+		return datasource.ErrInvalidAPIKey
+	case strings.Contains(text, "not verified"):
+		return datasource.ErrNotVerified
+	case strings.Contains(text, "no transactions found"), strings.Contains(text, "no records found"),
+		strings.Contains(text, "no data found"), strings.Contains(text, "not found"):
+		return datasource.ErrNotFound
+	default:
+		return nil
+	}
+}
+
+func (e *ether) misconfigured() error {
+	return fmt.Errorf("%w for %s", datasource.ErrMisconfigured, e.source)
+}
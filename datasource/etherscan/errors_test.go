@@ -0,0 +1,75 @@
+package etherscan
+
+import (
+	"errors"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"rate limit", `{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`, datasource.ErrRateLimited},
+		{"api key", `{"status":"0","message":"NOTOK","result":"Invalid API Key"}`, datasource.ErrInvalidAPIKey},
+		{"not verified", `{"status":"0","message":"NOTOK","result":"Contract source code not verified"}`, datasource.ErrNotVerified},
+		{"not found", `{"status":"0","message":"No transactions found","result":[]}`, datasource.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			e := NewEther("etherscan", srv.URL, "key", nil)
+			_, err := e.GetABIData("0x0")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got: %v", tt.want, err)
+			}
+
+			// rate limited answers are retried, the requester reports them once retries run out
+			var serviceErr *datasource.ServiceError
+			if tt.want != datasource.ErrRateLimited && !errors.As(err, &serviceErr) {
+				t.Fatalf("expected a ServiceError, got: %T", err)
+			}
+		})
+	}
+}
+
+func TestIsVerifyCodeNotVerified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Contract source code not verified"}`))
+	}))
+	defer srv.Close()
+
+	verified, err := NewEther("etherscan", srv.URL, "key", nil).IsVerifyCode("0x0")
+	if err != nil || verified {
+		t.Fatalf("expected unverified without error, got: %v, %v", verified, err)
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+	}))
+	defer srv.Close()
+
+	e := NewEther("etherscan", srv.URL, "key", &datasource.Requester{Cache: datasource.NewMemoryCache(0)})
+	for i := 0; i < 2; i++ {
+		if _, err := e.GetABIData("0xa"); !errors.Is(err, datasource.ErrInvalidAPIKey) {
+			t.Fatalf("expected ErrInvalidAPIKey, got: %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected the error answer not to be cached, got %d calls", calls)
+	}
+}
@@ -0,0 +1,165 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/url"
+)
+
+// MaxResultWindow is the number of records etherscan returns for one block range,
+// page * offset past it is rejected.
+const MaxResultWindow = 10000
+
+const defaultPageSize = 1000
+
+type TxAction string
+
+const (
+	TxListAction         TxAction = "txlist"
+	InternalTxListAction TxAction = "txlistinternal"
+	TokenTxAction        TxAction = "tokentx"
+	NFTTxAction          TxAction = "tokennfttx"
+	ERC1155TxAction      TxAction = "token1155tx"
+)
+
+// TxIterator walks a transaction list page by page. Once MaxResultWindow is reached
+// it restarts from the block of the last record, startblock going up for Asc and
+// endblock going down for Desc, skipping the records of that block it already returned.
+type TxIterator struct {
+	ctx    context.Context
+	e      *ether
+	action TxAction
+	params url.Values
+	query  TxQuery
+
+	page int
+	buf  []*types.EtherTx
+	tx   *types.EtherTx
+	done bool
+	err  error
+
+	// how often each record of the last block was returned, a slid window fetches
+	// them again and skip holds those still to be dropped after a slide
+	lastBlock uint64
+	seen      map[string]int
+	skip      map[string]int
+}
+
+// NewTxIterator iterates action for address, contract or both, contract only applies
+// to token actions. Page in query is ignored, Offset sets the page size.
+func (e *ether) NewTxIterator(ctx context.Context, action TxAction, address, contract string, query *TxQuery) *TxIterator {
+	it := &TxIterator{ctx: ctx, e: e, action: action, params: url.Values{}, seen: make(map[string]int)}
+	if query != nil {
+		it.query = *query
+	}
+
+	if it.query.Offset <= 0 || it.query.Offset > MaxResultWindow {
+		it.query.Offset = defaultPageSize
+	}
+	if it.query.Sort == "" {
+		it.query.Sort = Asc
+	}
+
+	if address != "" {
+		it.params.Set("address", address)
+	}
+	if contract != "" && action != TxListAction && action != InternalTxListAction {
+		it.params.Set("contractaddress", contract)
+	}
+	return it
+}
+
+// Next advances to the next record, it returns false once the list is exhausted or on error.
+func (it *TxIterator) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+
+	it.tx, it.buf = it.buf[0], it.buf[1:]
+	if it.tx.BlockNumber != it.lastBlock {
+		it.lastBlock = it.tx.BlockNumber
+		it.seen = make(map[string]int)
+	}
+	it.seen[txKey(it.tx)]++
+	return true
+}
+
+func (it *TxIterator) Tx() *types.EtherTx {
+	return it.tx
+}
+
+func (it *TxIterator) Err() error {
+	return it.err
+}
+
+func (it *TxIterator) fetch() {
+	it.page++
+	if it.page*it.query.Offset > MaxResultWindow {
+		if err := it.slide(); err != nil {
+			it.err = err
+			return
+		}
+	}
+
+	query := it.query
+	query.Page = it.page
+	params := query.values()
+	for k, v := range it.params {
+		params[k] = v
+	}
+
+	txs, err := it.e.txList(it.ctx, string(it.action), params)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	if len(txs) < it.query.Offset {
+		it.done = true
+	}
+
+	for _, tx := range txs {
+		// identical records are legitimate, only drop as many as were already returned
+		if it.skip != nil && tx.BlockNumber == it.lastBlock {
+			if key := txKey(tx); it.skip[key] > 0 {
+				it.skip[key]--
+				continue
+			}
+		} else {
+			it.skip = nil
+		}
+		it.buf = append(it.buf, tx)
+	}
+}
+
+// slide restarts the window at the last returned block, which may have been cut mid-way.
+func (it *TxIterator) slide() error {
+	if it.query.Sort == Desc {
+		if it.query.EndBlock == it.lastBlock {
+			return fmt.Errorf("block %d holds more than %d records for %s", it.lastBlock, MaxResultWindow, it.action)
+		}
+		it.query.EndBlock = it.lastBlock
+	} else {
+		if it.query.StartBlock == it.lastBlock {
+			return fmt.Errorf("block %d holds more than %d records for %s", it.lastBlock, MaxResultWindow, it.action)
+		}
+		it.query.StartBlock = it.lastBlock
+	}
+
+	it.skip = make(map[string]int, len(it.seen))
+	for key, count := range it.seen {
+		it.skip[key] = count
+	}
+	it.page = 1
+	return nil
+}
+
+// txKey tells records apart, a hash alone is shared by the internal calls and token
+// transfers of one transaction.
+func txKey(tx *types.EtherTx) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%v|%v|%v", tx.Hash, tx.TraceId, tx.ContractAddress, tx.From, tx.To, tx.Type, tx.Value, tx.TokenID, tx.TokenValue)
+}
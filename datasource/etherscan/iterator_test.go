@@ -0,0 +1,84 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestTxIteratorSlidesWindow(t *testing.T) {
+	type record struct {
+		BlockNumber string `json:"blockNumber"`
+		Hash        string `json:"hash"`
+	}
+
+	var all []record
+	for i := 0; i < 12000; i++ {
+		all = append(all, record{BlockNumber: strconv.Itoa(100 + i/3), Hash: fmt.Sprintf("0x%d", i)})
+	}
+
+	e := newTestEther(t, func(query url.Values) string {
+		start, _ := strconv.Atoi(query.Get("startblock"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if page*offset > MaxResultWindow {
+			return `{"status":"0","message":"NOTOK","result":"Result window is too large"}`
+		}
+
+		var window []record
+		for _, r := range all {
+			if block, _ := strconv.Atoi(r.BlockNumber); block >= start {
+				window = append(window, r)
+			}
+		}
+
+		from, to := (page-1)*offset, page*offset
+		if from > len(window) {
+			from = len(window)
+		}
+		if to > len(window) {
+			to = len(window)
+		}
+		result, _ := json.Marshal(window[from:to])
+		return `{"status":"1","message":"OK","result":` + string(result) + `}`
+	})
+
+	it := e.NewTxIterator(context.Background(), TxListAction, "0xa", "", &TxQuery{Offset: 5000})
+	seen := make(map[string]bool)
+	for it.Next() {
+		if seen[it.Tx().Hash] {
+			t.Fatalf("duplicate tx %s", it.Tx().Hash)
+		}
+		seen[it.Tx().Hash] = true
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("err: %s", it.Err())
+	}
+	if len(seen) != len(all) {
+		t.Fatalf("expected %d txs, got %d", len(all), len(seen))
+	}
+}
+
+func TestTxIteratorKeepsIdenticalRecords(t *testing.T) {
+	transfer := `{"blockNumber":"100","hash":"0xa1","from":"0xa","to":"0xb","value":"1"}`
+	e := newTestEther(t, func(query url.Values) string {
+		if query.Get("page") == "1" || query.Get("page") == "2" {
+			return `{"status":"1","message":"OK","result":[` + transfer + `]}`
+		}
+		return `{"status":"0","message":"No transactions found","result":[]}`
+	})
+
+	it := e.NewTxIterator(context.Background(), TokenTxAction, "0xa", "", &TxQuery{Offset: 1})
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	if it.Err() != nil || count != 2 {
+		t.Fatalf("expected both transfers, got %d, %v", count, it.Err())
+	}
+}
@@ -0,0 +1,128 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// api document: https://docs.etherscan.io/api-endpoints/logs
+
+type TopicOperator string
+
+const (
+	And TopicOperator = "and"
+	Or  TopicOperator = "or"
+)
+
+// LogQuery filters getLogs. Topics left empty are not filtered on, Operators joins
+// Topics[i] and Topics[j] keyed by [2]int{i, j} with i < j, pairs of set topics
+// without an operator are joined with And. A zero ToBlock means latest.
+type LogQuery struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Address   string
+	Topics    [4]string
+	Operators map[[2]int]TopicOperator
+	Page      int
+	Offset    int
+	// Decode decodes every log against the ABI GetABIData returns for its address,
+	// logs of unverified contracts or unknown events keep a nil Event.
+	Decode bool
+}
+
+func (q *LogQuery) values() url.Values {
+	params := url.Values{}
+	params.Set("fromBlock", strconv.FormatUint(q.FromBlock, 10))
+	if q.ToBlock == 0 {
+		params.Set("toBlock", "latest")
+	} else {
+		params.Set("toBlock", strconv.FormatUint(q.ToBlock, 10))
+	}
+
+	if q.Address != "" {
+		params.Set("address", q.Address)
+	}
+
+	for i, topic := range q.Topics {
+		if topic == "" {
+			continue
+		}
+		params.Set("topic"+strconv.Itoa(i), topic)
+
+		for j := i + 1; j < len(q.Topics); j++ {
+			if q.Topics[j] == "" {
+				continue
+			}
+
+			operator, ok := q.Operators[[2]int{i, j}]
+			if !ok {
+				operator = And
+			}
+			params.Set(fmt.Sprintf("topic%d_%d_opr", i, j), string(operator))
+		}
+	}
+
+	if q.Page != 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Offset != 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+	return params
+}
+
+func (e *ether) GetLogs(ctx context.Context, query *LogQuery) ([]*types.EtherLog, error) {
+	if query == nil {
+		query = &LogQuery{}
+	}
+
+	var logs []*types.EtherLog
+	if err := e.call(ctx, "logs", "getLogs", query.values(), &logs); err != nil {
+		return nil, err
+	}
+
+	if query.Decode {
+		if err := e.DecodeLogs(ctx, logs); err != nil {
+			return nil, err
+		}
+	}
+	return logs, nil
+}
+
+// DecodeLogs sets the Event of every log it finds an ABI and a matching event for,
+// fetching the ABI of each distinct address once. Logs no ABI decodes fall back
+// to the signature database when one is set.
+func (e *ether) DecodeLogs(ctx context.Context, logs []*types.EtherLog) error {
+	entries := make(map[string][]types.ABIEntry)
+	for _, log := range logs {
+		address := strings.ToLower(log.Address)
+		abiEntries, ok := entries[address]
+		if !ok {
+			abiJSON, err := e.GetABIDataCtx(ctx, log.Address)
+			if err != nil && !errors.Is(err, datasource.ErrNotVerified) {
+				return err
+			}
+
+			if err == nil {
+				if abiEntries, err = abi.ParseEntries(abiJSON); err != nil {
+					return err
+				}
+			}
+			entries[address] = abiEntries
+		}
+
+		if event, err := abi.DecodeLog(abiEntries, log.Topics, log.Data); err == nil {
+			log.Event = event
+		} else if e.signatures != nil {
+			log.Event, _ = e.signatures.DecodeLog(log.Topics, log.Data)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestGetLogsDecode(t *testing.T) {
+	const transfer = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	e := newTestEther(t, func(query url.Values) string {
+		switch query.Get("action") {
+		case "getLogs":
+			if query.Get("topic0") != transfer || query.Get("topic0_2_opr") != "or" || query.Get("toBlock") != "latest" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"status":"1","message":"OK","result":[{"address":"0xa","topics":["` + transfer + `",
+				"0x0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+				"0x0000000000000000000000000000000000000000000000000000000000000001"],
+				"data":"0x00000000000000000000000000000000000000000000000000000000000003e8",
+				"blockNumber":"0xe30af1","timeStamp":"0x62a06aec","gasPrice":"0x1","gasUsed":"0xb4c5","logIndex":"0x","transactionHash":"0xc5","transactionIndex":"0x2"}]}`
+		case "getabi":
+			return `{"status":"1","message":"OK","result":"[{\"type\":\"event\",\"name\":\"Transfer\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true},{\"name\":\"to\",\"type\":\"address\",\"indexed\":true},{\"name\":\"value\",\"type\":\"uint256\"}]}]"}`
+		default:
+			return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+		}
+	})
+
+	logs, err := e.GetLogs(context.Background(), &LogQuery{
+		FromBlock: 1,
+		Topics:    [4]string{transfer, "", "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		Operators: map[[2]int]TopicOperator{{0, 2}: Or},
+		Decode:    true,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	log := logs[0]
+	if log.BlockNumber != 0xe30af1 || log.LogIndex != 0 || log.TransactionIndex != 2 || log.TimeStamp.Unix() != 0x62a06aec {
+		t.Fatalf("unexpected log: %+v", log)
+	}
+
+	if log.Event == nil || log.Event.Name != "Transfer" || fmt.Sprint(log.Event.Args[2].Value) != "1000" {
+		t.Fatalf("unexpected event: %+v", log.Event)
+	}
+}
@@ -0,0 +1,173 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"github.com/imroc/req"
+	"math/big"
+	"net/url"
+	"strconv"
+)
+
+// CallMsg is the message of eth_call and eth_estimateGas, zero fields are left out.
+type CallMsg struct {
+	To       string
+	Data     string
+	Value    *big.Int
+	Gas      *big.Int
+	GasPrice *big.Int
+}
+
+func (m *CallMsg) values() url.Values {
+	params := url.Values{}
+	if m == nil {
+		return params
+	}
+
+	if m.To != "" {
+		params.Set("to", m.To)
+	}
+	if m.Data != "" {
+		params.Set("data", m.Data)
+	}
+
+	for name, value := range map[string]*big.Int{"value": m.Value, "gas": m.Gas, "gasPrice": m.GasPrice} {
+		if value != nil {
+			params.Set(name, "0x"+value.Text(16))
+		}
+	}
+	return params
+}
+
+// BlockTag returns the hex quantity the proxy module expects for block number.
+func BlockTag(number uint64) string {
+	return "0x" + strconv.FormatUint(number, 16)
+}
+
+func (e *ether) GetBlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := e.rpc(ctx, "eth_blockNumber", nil, &number)
+	return number, err
+}
+
+// GetBlockByNumber returns block number, with its full transactions when full is set.
+func (e *ether) GetBlockByNumber(ctx context.Context, number uint64, full bool) (*types.EtherBlock, error) {
+	params := url.Values{"tag": {BlockTag(number)}, "boolean": {strconv.FormatBool(full)}}
+	var raw map[string]interface{}
+	if err := e.rpc(ctx, "eth_getBlockByNumber", params, &raw); err != nil {
+		return nil, err
+	}
+
+	transactions := raw["transactions"]
+	delete(raw, "transactions")
+
+	block := &types.EtherBlock{}
+	if err := decode(raw, block); err != nil {
+		return nil, err
+	}
+
+	if full {
+		return block, decode(transactions, &block.Transactions)
+	}
+	return block, decode(transactions, &block.TransactionHashes)
+}
+
+func (e *ether) GetTransactionByHash(ctx context.Context, hash string) (*types.EtherRPCTx, error) {
+	tx := &types.EtherRPCTx{}
+	if err := e.rpc(ctx, "eth_getTransactionByHash", url.Values{"txhash": {hash}}, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction, pending ones are ErrNotFound.
+func (e *ether) GetTransactionReceipt(ctx context.Context, hash string) (*types.EtherReceipt, error) {
+	receipt := &types.EtherReceipt{}
+	if err := e.rpc(ctx, "eth_getTransactionReceipt", url.Values{"txhash": {hash}}, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// Call executes msg against the state at tag without sending a transaction and
+// returns the hex encoded return data.
+func (e *ether) Call(ctx context.Context, msg *CallMsg, tag string) (string, error) {
+	params := msg.values()
+	params.Set("tag", blockTag(tag))
+
+	var data string
+	err := e.rpc(ctx, "eth_call", params, &data)
+	return data, err
+}
+
+func (e *ether) GetCode(ctx context.Context, address, tag string) (string, error) {
+	var code string
+	err := e.rpc(ctx, "eth_getCode", url.Values{"address": {address}, "tag": {blockTag(tag)}}, &code)
+	return code, err
+}
+
+// GetStorageAt returns the 32 bytes storage slot position of address as hex, a nil position is slot 0.
+func (e *ether) GetStorageAt(ctx context.Context, address string, position *big.Int, tag string) (string, error) {
+	if position == nil {
+		position = new(big.Int)
+	}
+
+	params := url.Values{"address": {address}, "position": {"0x" + position.Text(16)}, "tag": {blockTag(tag)}}
+	var value string
+	err := e.rpc(ctx, "eth_getStorageAt", params, &value)
+	return value, err
+}
+
+// GetGasPrice returns the gas price in wei.
+func (e *ether) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	var price string
+	if err := e.rpc(ctx, "eth_gasPrice", nil, &price); err != nil {
+		return nil, err
+	}
+	return parseBig(price)
+}
+
+func (e *ether) EstimateGas(ctx context.Context, msg *CallMsg) (*big.Int, error) {
+	var gas string
+	if err := e.rpc(ctx, "eth_estimateGas", msg.values(), &gas); err != nil {
+		return nil, err
+	}
+	return parseBig(gas)
+}
+
+// rpc requests action of the proxy module, which answers json-rpc envelopes
+// rather than etherscan's status and message. A null result is ErrNotFound.
+func (e *ether) rpc(ctx context.Context, action string, params url.Values, out interface{}) error {
+	if !e.check() {
+		return e.misconfigured()
+	}
+
+	net := datasource.NewNet(e.endpoint("proxy", action, params), req.Header{}, req.Param{}, datasource.GET)
+	resp, err := e.requester.Do(ctx, net.SetRetryIf(isRateLimited))
+	if err != nil {
+		return err
+	}
+
+	res := &types.EtherRPCResult{}
+	if err = json.Unmarshal(resp, res); err != nil {
+		return err
+	}
+
+	switch {
+	case res.Error != nil:
+		return &datasource.ServiceError{
+			Source:  e.source,
+			Code:    strconv.Itoa(res.Error.Code),
+			Message: res.Error.Message,
+			Err:     classify(res.Error.Message),
+		}
+	case res.Status != "" && res.Status != "1":
+		return e.serviceError(&types.EtherResult{Status: res.Status, Message: res.Message, Result: res.Result})
+	case res.Result == nil:
+		return fmt.Errorf("%w: %s", datasource.ErrNotFound, action)
+	}
+	return decode(res.Result, out)
+}
@@ -0,0 +1,79 @@
+package etherscan
+
+import (
+	"context"
+	"errors"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"math/big"
+	"net/url"
+	"testing"
+)
+
+func TestProxyModule(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		if query.Get("module") != "proxy" {
+			t.Errorf("unexpected query: %s", query.Encode())
+		}
+
+		switch query.Get("action") {
+		case "eth_blockNumber":
+			return `{"jsonrpc":"2.0","id":83,"result":"0xe3c57c"}`
+		case "eth_getBlockByNumber":
+			if query.Get("tag") != "0x10d4f" || query.Get("boolean") != "false" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"jsonrpc":"2.0","id":1,"result":{"number":"0x10d4f","hash":"0x7eb7","timestamp":"0x55ba467c","gasUsed":"0x5208","baseFeePerGas":null,"transactions":["0xa1","0xa2"],"uncles":[]}}`
+		case "eth_getTransactionReceipt":
+			return `{"jsonrpc":"2.0","id":1,"result":null}`
+		case "eth_estimateGas":
+			if query.Get("to") != "0xb" || query.Get("value") != "0xff22" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"jsonrpc":"2.0","id":1,"result":"0x5208"}`
+		case "eth_getStorageAt":
+			if query.Get("position") != "0x0" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000001"}`
+		case "eth_call":
+			return `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`
+		}
+		return `{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`
+	})
+	ctx := context.Background()
+
+	number, err := e.GetBlockNumber(ctx)
+	if err != nil || number != 14927228 {
+		t.Fatalf("unexpected block number: %d, %v", number, err)
+	}
+
+	block, err := e.GetBlockByNumber(ctx, 68943, false)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if block.Number != 68943 || block.TimeStamp.Unix() != 1438271100 || block.GasUsed.Int64() != 21000 ||
+		len(block.TransactionHashes) != 2 || block.Transactions != nil {
+		t.Fatalf("unexpected block: %+v", block)
+	}
+
+	if _, err = e.GetTransactionReceipt(ctx, "0xpending"); !errors.Is(err, datasource.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+
+	gas, err := e.EstimateGas(ctx, &CallMsg{To: "0xb", Value: big.NewInt(0xff22)})
+	if err != nil || gas.Int64() != 21000 {
+		t.Fatalf("unexpected gas: %v, %v", gas, err)
+	}
+
+	var serviceErr *datasource.ServiceError
+	if _, err = e.Call(ctx, &CallMsg{To: "0xb", Data: "0x"}, ""); !errors.As(err, &serviceErr) || serviceErr.Message != "execution reverted" {
+		t.Fatalf("expected the json-rpc error, got: %v", err)
+	}
+	if _, err = e.Call(ctx, nil, ""); !errors.As(err, &serviceErr) {
+		t.Fatalf("expected a nil message to be sent empty, got: %v", err)
+	}
+
+	if _, err = e.GetStorageAt(ctx, "0xb", nil, ""); err != nil {
+		t.Fatalf("expected a nil position to read slot 0, got: %v", err)
+	}
+}
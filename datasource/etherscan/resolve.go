@@ -0,0 +1,101 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"strings"
+)
+
+// MaxProxyDepth bounds the number of contracts ResolveContract reads along a
+// proxy chain, the implementation included.
+const MaxProxyDepth = 8
+
+// SetProxyResolution makes GetSourceCode and GetABIData follow proxies to their implementation.
+func (e *ether) SetProxyResolution(enable bool) *ether {
+	e.resolveProxy = enable
+	return e
+}
+
+// ResolveContract follows Implementation while the source code of contract is
+// marked as a proxy. A loop or a chain not reaching its implementation within
+// MaxProxyDepth contracts fails with ErrMisconfigured. The ABI merges the ABIs of the whole chain, the
+// implementation's entries winning.
+func (e *ether) ResolveContract(ctx context.Context, contract string) (*types.ResolvedContract, error) {
+	resolved := &types.ResolvedContract{Address: contract}
+	visited := make(map[string]bool)
+
+	address := contract
+	for depth := 0; ; depth++ {
+		if visited[strings.ToLower(address)] {
+			return nil, fmt.Errorf("%w: proxy loop: %s -> %s", datasource.ErrMisconfigured, strings.Join(resolved.Chain, " -> "), address)
+		}
+		if depth >= MaxProxyDepth {
+			return nil, fmt.Errorf("%w: no implementation within %d contracts: %s -> %s", datasource.ErrMisconfigured, MaxProxyDepth, strings.Join(resolved.Chain, " -> "), address)
+		}
+		visited[strings.ToLower(address)] = true
+
+		codes, err := e.getSourceCode(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(codes) == 0 {
+			return nil, fmt.Errorf("%w: source code of %s", datasource.ErrNotFound, address)
+		}
+
+		code := codes[0]
+		resolved.Chain = append(resolved.Chain, address)
+		resolved.Sources = append(resolved.Sources, code)
+		resolved.Implementation = code
+
+		if code.Proxy != "1" || code.Implementation == "" {
+			break
+		}
+		address = code.Implementation
+	}
+
+	var lists [][]types.ABIEntry
+	for i := len(resolved.Sources) - 1; i >= 0; i-- {
+		// unverified contracts carry a message instead of an ABI
+		if entries, err := abi.ParseEntries(resolved.Sources[i].ABI); err == nil {
+			lists = append(lists, entries)
+		}
+	}
+
+	if len(lists) != 0 {
+		merged, err := abi.MarshalEntries(abi.Merge(lists...))
+		if err != nil {
+			return nil, err
+		}
+		resolved.ABI = merged
+	}
+	return resolved, nil
+}
+
+func (e *ether) resolvedABI(ctx context.Context, contract string) (string, error) {
+	resolved, err := e.ResolveContract(ctx, contract)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved.ABI == "" {
+		return "", fmt.Errorf("%w: %s", datasource.ErrNotVerified, contract)
+	}
+	return resolved.ABI, nil
+}
+
+func (e *ether) resolvedSourceCode(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
+	resolved, err := e.ResolveContract(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	code := *resolved.Implementation
+	if resolved.ABI != "" {
+		code.ABI = resolved.ABI
+	}
+	return []*types.EtherSourceCode{&code}, nil
+}
@@ -0,0 +1,94 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func sourceCodeAnswer(abiJSON, proxy, implementation string) string {
+	record, _ := json.Marshal([]map[string]string{{
+		"SourceCode":     "contract C {}",
+		"ABI":            abiJSON,
+		"ContractName":   "C",
+		"Proxy":          proxy,
+		"Implementation": implementation,
+	}})
+	return `{"status":"1","message":"OK","result":` + string(record) + `}`
+}
+
+func TestResolveContract(t *testing.T) {
+	const (
+		proxyABI = `[{"type":"function","name":"upgradeTo","inputs":[{"name":"impl","type":"address"}]},{"type":"fallback"}]`
+		implABI  = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]},{"type":"fallback"}]`
+	)
+
+	fetched, limit := 0, MaxProxyDepth
+	e := newTestEther(t, func(query url.Values) string {
+		switch strings.ToLower(query.Get("address")) {
+		case "0xproxy":
+			return sourceCodeAnswer(proxyABI, "1", "0xImpl")
+		case "0ximpl":
+			return sourceCodeAnswer(implABI, "0", "")
+		case "0xloop":
+			return sourceCodeAnswer("Contract source code not verified", "1", "0xLoop")
+		}
+
+		// 0xchain1 -> 0xchain2 -> ... proxies up to 0xchain<limit>
+		if hop, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(query.Get("address")), "0xchain")); err == nil {
+			fetched++
+			if hop == limit {
+				return sourceCodeAnswer(implABI, "0", "")
+			}
+			return sourceCodeAnswer(proxyABI, "1", "0xChain"+strconv.Itoa(hop+1))
+		}
+		return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+	})
+
+	resolved, err := e.ResolveContract(context.Background(), "0xProxy")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	entries, err := abi.ParseEntries(resolved.ABI)
+	if err != nil {
+		t.Fatalf("merged abi: %s", err)
+	}
+	if fmt.Sprint(resolved.Chain) != "[0xProxy 0xImpl]" || len(entries) != 3 || entries[0].Name != "transfer" {
+		t.Fatalf("unexpected resolution: %v, %s", resolved.Chain, resolved.ABI)
+	}
+
+	if _, err = e.ResolveContract(context.Background(), "0xLoop"); !errors.Is(err, datasource.ErrMisconfigured) || !strings.Contains(err.Error(), "0xLoop -> 0xLoop") {
+		t.Fatalf("expected the loop to fail, got: %v", err)
+	}
+
+	resolved, err = e.ResolveContract(context.Background(), "0xChain1")
+	if err != nil || len(resolved.Chain) != MaxProxyDepth || fetched != MaxProxyDepth {
+		t.Fatalf("expected a chain of MaxProxyDepth contracts to resolve, got: %d contracts in %d fetches, %v", len(resolved.Chain), fetched, err)
+	}
+
+	fetched, limit = 0, MaxProxyDepth+1
+	if _, err = e.ResolveContract(context.Background(), "0xChain1"); !errors.Is(err, datasource.ErrMisconfigured) || fetched != MaxProxyDepth {
+		t.Fatalf("expected a longer chain to fail after MaxProxyDepth fetches, got %d fetches, %v", fetched, err)
+	}
+
+	abiJSON, err := e.SetProxyResolution(true).GetABIData("0xProxy")
+	if err != nil || abiJSON != mustMarshal(t, entries) {
+		t.Fatalf("unexpected resolved abi: %s, %v", abiJSON, err)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	return string(data)
+}
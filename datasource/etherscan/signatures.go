@@ -0,0 +1,22 @@
+package etherscan
+
+import "github.com/ThreeAndTwo/chainscan-api/abi"
+
+// SetSignatureDB records the signatures of every ABI fetched in db and falls back
+// to it when decoding calls and logs of unverified contracts.
+func (e *ether) SetSignatureDB(db *abi.SignatureDB) *ether {
+	e.signatures = db
+	return e
+}
+
+// remember adds the signatures of abiJSON to the signature database, unverified
+// contracts carry a message instead of an ABI and are skipped.
+func (e *ether) remember(abiJSON string) {
+	if e.signatures == nil {
+		return
+	}
+
+	if parsed, err := abi.Parse(abiJSON); err == nil {
+		_ = e.signatures.AddABI(parsed)
+	}
+}
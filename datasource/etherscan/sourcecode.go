@@ -0,0 +1,202 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type standardInput struct {
+	Language string `json:"language"`
+	Sources  map[string]struct {
+		Content string `json:"content"`
+	} `json:"sources"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+type standardSettings struct {
+	Remappings []string                     `json:"remappings"`
+	Optimizer  types.Optimizer              `json:"optimizer"`
+	EVMVersion string                       `json:"evmVersion"`
+	Libraries  map[string]map[string]string `json:"libraries"`
+}
+
+// ParseSourceCode turns the SourceCode of a verified contract into its file tree.
+// SourceCode is either a single source file, a json object of files, or a standard
+// json input wrapped in an extra pair of braces.
+func ParseSourceCode(code *types.EtherSourceCode) (*types.ContractSources, error) {
+	raw := strings.TrimSpace(code.SourceCode)
+	if raw == "" {
+		return nil, fmt.Errorf("empty source code for %s", code.ContractName)
+	}
+
+	if strings.HasPrefix(raw, "{{") && strings.HasSuffix(raw, "}}") {
+		raw = raw[1 : len(raw)-1]
+	}
+
+	if !strings.HasPrefix(raw, "{") {
+		return singleFile(code, raw), nil
+	}
+
+	input := &standardInput{}
+	if err := json.Unmarshal([]byte(raw), input); err != nil {
+		return nil, fmt.Errorf("invalid source code json for %s: %w", code.ContractName, err)
+	}
+
+	if input.Sources == nil {
+		// a bare object of path: {content}
+		files := make(map[string]struct {
+			Content string `json:"content"`
+		})
+		if err := json.Unmarshal([]byte(raw), &files); err != nil {
+			return nil, fmt.Errorf("invalid source code json for %s: %w", code.ContractName, err)
+		}
+		input.Sources = files
+	}
+
+	sources := &types.ContractSources{
+		Language: input.Language,
+		Sources:  make(map[string]string, len(input.Sources)),
+		Settings: input.Settings,
+	}
+	if sources.Language == "" {
+		sources.Language = language(code)
+	}
+
+	for path, file := range input.Sources {
+		sources.Sources[path] = file.Content
+	}
+
+	if len(input.Settings) != 0 {
+		settings := &standardSettings{}
+		if err := json.Unmarshal(input.Settings, settings); err != nil {
+			return nil, fmt.Errorf("invalid settings for %s: %w", code.ContractName, err)
+		}
+		sources.Remappings = settings.Remappings
+		sources.Optimizer = settings.Optimizer
+		sources.EVMVersion = settings.EVMVersion
+		sources.Libraries = settings.Libraries
+	} else {
+		applyMetadata(sources, code, "")
+	}
+	return sources, nil
+}
+
+func singleFile(code *types.EtherSourceCode, content string) *types.ContractSources {
+	ext := ".sol"
+	if language(code) == "Vyper" {
+		ext = ".vy"
+	}
+
+	path := code.ContractName + ext
+	sources := &types.ContractSources{
+		Language: language(code),
+		Sources:  map[string]string{path: content},
+	}
+	applyMetadata(sources, code, path)
+	return sources
+}
+
+// applyMetadata fills in what the explorer reports next to the source code, libraries
+// are linked to file, or to every file when file is empty.
+func applyMetadata(sources *types.ContractSources, code *types.EtherSourceCode, file string) {
+	sources.Optimizer.Enabled = code.OptimizationUsed == "1"
+	sources.Optimizer.Runs, _ = strconv.Atoi(code.Runs)
+	if !strings.EqualFold(code.EVMVersion, "default") {
+		sources.EVMVersion = code.EVMVersion
+	}
+
+	libraries := parseLibraries(code.Library)
+	if len(libraries) == 0 {
+		return
+	}
+
+	sources.Libraries = make(map[string]map[string]string)
+	if file != "" {
+		sources.Libraries[file] = libraries
+		return
+	}
+	for path := range sources.Sources {
+		sources.Libraries[path] = libraries
+	}
+}
+
+func language(code *types.EtherSourceCode) string {
+	if strings.HasPrefix(strings.ToLower(code.CompilerVersion), "vyper") {
+		return "Vyper"
+	}
+	return "Solidity"
+}
+
+// parseLibraries reads the Library field, Name:address pairs separated by semicolons.
+func parseLibraries(library string) map[string]string {
+	libraries := make(map[string]string)
+	for _, link := range strings.Split(library, ";") {
+		parts := strings.SplitN(strings.TrimSpace(link), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+
+		address := strings.TrimSpace(parts[1])
+		if !strings.HasPrefix(address, "0x") {
+			address = "0x" + address
+		}
+		libraries[strings.TrimSpace(parts[0])] = address
+	}
+	return libraries
+}
+
+// WriteSources writes every file of sources under dir, plus remappings.txt when
+// remappings are set. Paths escaping dir are rejected.
+func WriteSources(dir string, sources *types.ContractSources) error {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(sources.Sources))
+	for path := range sources.Sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		if rel, err := filepath.Rel(root, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("source path %q escapes %s", path, dir)
+		}
+
+		if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err = os.WriteFile(target, []byte(sources.Sources[path]), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if len(sources.Remappings) != 0 {
+		remappings := strings.Join(sources.Remappings, "\n") + "\n"
+		return os.WriteFile(filepath.Join(root, "remappings.txt"), []byte(remappings), 0o644)
+	}
+	return nil
+}
+
+// GetContractSources fetches the source code of contract and parses its file tree.
+func (e *ether) GetContractSources(ctx context.Context, contract string) (*types.ContractSources, error) {
+	codes, err := e.GetSourceCodeCtx(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) == 0 || codes[0].SourceCode == "" {
+		return nil, fmt.Errorf("%w: %s", datasource.ErrNotVerified, contract)
+	}
+	return ParseSourceCode(codes[0])
+}
@@ -0,0 +1,74 @@
+package etherscan
+
+import (
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSourceCodeStandardJSON(t *testing.T) {
+	code := &types.EtherSourceCode{
+		ContractName:    "Token",
+		CompilerVersion: "v0.8.19+commit.7dd6d404",
+		SourceCode: `{{
+  "language": "Solidity",
+  "sources": {
+    "contracts/Token.sol": {"content": "import \"@oz/ERC20.sol\";"},
+    "@oz/ERC20.sol": {"content": "contract ERC20 {}"}
+  },
+  "settings": {
+    "remappings": ["@oz/=lib/oz/"],
+    "optimizer": {"enabled": true, "runs": 200},
+    "evmVersion": "paris",
+    "libraries": {"contracts/Token.sol": {"Math": "0x0000000000000000000000000000000000000001"}}
+  }
+}}`,
+	}
+
+	sources, err := ParseSourceCode(code)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if sources.Language != "Solidity" || len(sources.Sources) != 2 || !sources.Optimizer.Enabled || sources.Optimizer.Runs != 200 ||
+		sources.EVMVersion != "paris" || sources.Remappings[0] != "@oz/=lib/oz/" || sources.Libraries["contracts/Token.sol"]["Math"] == "" {
+		t.Fatalf("unexpected sources: %+v", sources)
+	}
+
+	dir := t.TempDir()
+	if err = WriteSources(dir, sources); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "@oz", "ERC20.sol"))
+	if err != nil || string(content) != "contract ERC20 {}" {
+		t.Fatalf("unexpected file: %s, %v", content, err)
+	}
+}
+
+func TestParseSourceCodeSingleFile(t *testing.T) {
+	sources, err := ParseSourceCode(&types.EtherSourceCode{
+		ContractName:     "Vault",
+		SourceCode:       "pragma solidity ^0.6.0; contract Vault {}",
+		OptimizationUsed: "1",
+		Runs:             "999",
+		EVMVersion:       "Default",
+		Library:          "SafeMath:8a6d4c8735371ebaf8874fbd518b56edd66024eb",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if sources.Sources["Vault.sol"] == "" || sources.Optimizer.Runs != 999 || sources.EVMVersion != "" ||
+		sources.Libraries["Vault.sol"]["SafeMath"] != "0x8a6d4c8735371ebaf8874fbd518b56edd66024eb" {
+		t.Fatalf("unexpected sources: %+v", sources)
+	}
+}
+
+func TestWriteSourcesRejectsEscapes(t *testing.T) {
+	sources := &types.ContractSources{Sources: map[string]string{"../evil.sol": ""}}
+	if err := WriteSources(t.TempDir(), sources); err == nil {
+		t.Fatal("expected an error for a path escaping the directory")
+	}
+}
@@ -0,0 +1,131 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ChainSizeQuery selects the days and node setup of GetChainSize. ClientType is
+// geth or parity and SyncMode default or archive.
+type ChainSizeQuery struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	ClientType string
+	SyncMode   string
+	Sort       Sort
+}
+
+func (q *ChainSizeQuery) values() url.Values {
+	params := url.Values{
+		"startdate":  {q.StartDate.UTC().Format(dateLayout)},
+		"enddate":    {q.EndDate.UTC().Format(dateLayout)},
+		"clienttype": {"geth"},
+		"syncmode":   {"default"},
+		"sort":       {string(Asc)},
+	}
+
+	if q.ClientType != "" {
+		params.Set("clienttype", q.ClientType)
+	}
+	if q.SyncMode != "" {
+		params.Set("syncmode", q.SyncMode)
+	}
+	if q.Sort != "" {
+		params.Set("sort", string(q.Sort))
+	}
+	return params
+}
+
+func (e *ether) GetGasOracle(ctx context.Context) (*types.EtherGasOracle, error) {
+	var raw map[string]interface{}
+	if err := e.call(ctx, "gastracker", "gasoracle", nil, &raw); err != nil {
+		return nil, err
+	}
+
+	// gasUsedRatio is a comma separated list within a single string
+	ratios, _ := raw["gasUsedRatio"].(string)
+	delete(raw, "gasUsedRatio")
+
+	oracle := &types.EtherGasOracle{}
+	if err := decode(raw, oracle); err != nil {
+		return nil, err
+	}
+
+	for _, ratio := range strings.Split(ratios, ",") {
+		if ratio = strings.TrimSpace(ratio); ratio == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(ratio, 64)
+		if err != nil {
+			return nil, err
+		}
+		oracle.GasUsedRatio = append(oracle.GasUsedRatio, value)
+	}
+	return oracle, nil
+}
+
+// GetGasEstimate returns the estimated time for a transaction paying gasPrice wei
+// per gas to be confirmed.
+func (e *ether) GetGasEstimate(ctx context.Context, gasPrice *big.Int) (time.Duration, error) {
+	if gasPrice == nil || gasPrice.Sign() < 0 {
+		return 0, fmt.Errorf("gasestimate requires a gas price of at least 0 wei, got %v", gasPrice)
+	}
+
+	var seconds int64
+	if err := e.call(ctx, "gastracker", "gasestimate", url.Values{"gasprice": {gasPrice.String()}}, &seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// GetEthSupply returns the ether in circulation in wei, excluding staking rewards and burnt fees.
+func (e *ether) GetEthSupply(ctx context.Context) (*big.Int, error) {
+	var supply string
+	if err := e.call(ctx, "stats", "ethsupply", nil, &supply); err != nil {
+		return nil, err
+	}
+	return parseBig(supply)
+}
+
+func (e *ether) GetEthSupply2(ctx context.Context) (*types.EtherSupply, error) {
+	supply := &types.EtherSupply{}
+	if err := e.call(ctx, "stats", "ethsupply2", nil, supply); err != nil {
+		return nil, err
+	}
+	return supply, nil
+}
+
+func (e *ether) GetEthPrice(ctx context.Context) (*types.EtherPrice, error) {
+	price := &types.EtherPrice{}
+	if err := e.call(ctx, "stats", "ethprice", nil, price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+func (e *ether) GetChainSize(ctx context.Context, query *ChainSizeQuery) ([]*types.EtherChainSize, error) {
+	if query == nil {
+		return nil, fmt.Errorf("chainsize requires a query with its start and end dates")
+	}
+
+	var sizes []*types.EtherChainSize
+	err := e.call(ctx, "stats", "chainsize", query.values(), &sizes)
+	return sizes, err
+}
+
+func (e *ether) GetNodeCount(ctx context.Context) (*types.EtherNodeCount, error) {
+	count := &types.EtherNodeCount{}
+	if err := e.call(ctx, "stats", "nodecount", nil, count); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
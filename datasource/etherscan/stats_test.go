@@ -0,0 +1,67 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGasTrackerAndStats(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		switch query.Get("module") + "." + query.Get("action") {
+		case "gastracker.gasoracle":
+			return `{"status":"1","message":"OK","result":{"LastBlock":"13053741","SafeGasPrice":"20","ProposeGasPrice":"22","FastGasPrice":"24.5","suggestBaseFee":"19.230609716","gasUsedRatio":"0.370119078,0.8867994,0.2"}}`
+		case "gastracker.gasestimate":
+			if query.Get("gasprice") != "2000000000" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"status":"1","message":"OK","result":"9633"}`
+		case "stats.ethprice":
+			return `{"status":"1","message":"OK","result":{"ethbtc":"0.06116","ethbtc_timestamp":"1624961308","ethusd":"2149.18","ethusd_timestamp":"1624961308"}}`
+		case "stats.chainsize":
+			if query.Get("startdate") != "2019-02-01" || query.Get("clienttype") != "geth" || query.Get("syncmode") != "archive" {
+				t.Errorf("unexpected query: %s", query.Encode())
+			}
+			return `{"status":"1","message":"OK","result":[{"blockNumber":"7156164","chainTimeStamp":"2019-02-01","chainSize":"184726421279","clientType":"Geth","syncMode":"Archive"}]}`
+		}
+		return `{"status":"0","message":"NOTOK","result":"unexpected"}`
+	})
+	ctx := context.Background()
+
+	oracle, err := e.GetGasOracle(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if oracle.LastBlock != 13053741 || oracle.FastGasPrice != 24.5 || oracle.SuggestBaseFee != 19.230609716 ||
+		fmt.Sprint(oracle.GasUsedRatio) != "[0.370119078 0.8867994 0.2]" {
+		t.Fatalf("unexpected oracle: %+v", oracle)
+	}
+
+	estimate, err := e.GetGasEstimate(ctx, big.NewInt(2000000000))
+	if err != nil || estimate != 9633*time.Second {
+		t.Fatalf("unexpected estimate: %s, %v", estimate, err)
+	}
+
+	price, err := e.GetEthPrice(ctx)
+	if err != nil || price.ETHUSD != 2149.18 || price.ETHUSDTimeStamp.Unix() != 1624961308 {
+		t.Fatalf("unexpected price: %+v, %v", price, err)
+	}
+
+	day := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	sizes, err := e.GetChainSize(ctx, &ChainSizeQuery{StartDate: day, EndDate: day.AddDate(0, 0, 1), SyncMode: "archive"})
+	if err != nil || len(sizes) != 1 || !sizes[0].ChainTimeStamp.Equal(day) || sizes[0].ChainSize.String() != "184726421279" {
+		t.Fatalf("unexpected sizes: %+v, %v", sizes, err)
+	}
+	if _, err = e.GetGasEstimate(ctx, nil); err == nil {
+		t.Fatal("expected a nil gas price to fail")
+	}
+	if _, err = e.GetGasEstimate(ctx, big.NewInt(-1)); err == nil {
+		t.Fatal("expected a negative gas price to fail")
+	}
+	if _, err = e.GetChainSize(ctx, nil); err == nil {
+		t.Fatal("expected a nil query to fail")
+	}
+}
@@ -1,32 +1,48 @@
 package etherscan
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/abi"
 	"github.com/ThreeAndTwo/chainscan-api/datasource"
 	"github.com/ThreeAndTwo/chainscan-api/types"
 	"github.com/imroc/req"
 	"github.com/mitchellh/mapstructure"
-	"golang.org/x/time/rate"
+	"net/url"
 )
 
 type ether struct {
-	source      string
-	url         string
-	apiKey      string
-	rateLimiter *rate.Limiter
+	source       string
+	url          string
+	apiKey       string
+	requester    *datasource.Requester
+	resolveProxy bool
+	signatures   *abi.SignatureDB
+	chainID      uint64
+	decimals     *decimalsCache
 }
 
-func NewEther(source, url, apiKey string, rate *rate.Limiter) *ether {
+func NewEther(source, url, apiKey string, requester *datasource.Requester) *ether {
 	if url[len(url)-1:] != "?" {
 		url += "?"
 	}
 
-	return &ether{source: source, url: url, apiKey: apiKey, rateLimiter: rate}
+	return &ether{source: source, url: url, apiKey: apiKey, requester: requester, decimals: &decimalsCache{}}
 }
 
 func (e *ether) GetMarketInfoForCoin() ([]*types.MarketInfo, error) {
-	return nil, fmt.Errorf("unSupport for %s source", e.source)
+	return e.GetMarketInfoForCoinCtx(context.Background())
+}
+
+func (e *ether) GetMarketInfoForCoinCtx(ctx context.Context) ([]*types.MarketInfo, error) {
+	return nil, fmt.Errorf("%w for %s source", datasource.ErrUnsupported, e.source)
+}
+
+func (e *ether) request(ctx context.Context, url string) ([]byte, error) {
+	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET).SetRetryIf(isRateLimited).SetCacheIf(isOK)
+	return e.requester.Do(ctx, net)
 }
 
 func (e *ether) check() bool {
@@ -34,28 +50,25 @@ func (e *ether) check() bool {
 }
 
 func (e *ether) GetTokenInfo(contract string) (*types.TokenInfo, error) {
-	if !e.check() {
-		return nil, fmt.Errorf("config mismatched for %s", e.source)
-	}
+	return e.GetTokenInfoCtx(context.Background(), contract)
+}
 
-	url := e.url + "module=token&action=tokeninfo&address=" + contract + "&apiKey=" + e.apiKey
-	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET)
-	resp, err := net.Request()
-	if err != nil {
-		return nil, err
+func (e *ether) GetTokenInfoCtx(ctx context.Context, contract string) (*types.TokenInfo, error) {
+	if !e.check() {
+		return nil, e.misconfigured()
 	}
 
-	res := &types.EtherResult{}
-	err = json.Unmarshal(resp, res)
+	var infos []types.EtherTokenInfo
+	err := e.call(ctx, "token", "tokeninfo", url.Values{"address": {contract}}, &infos)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Status != "1" {
-		return nil, fmt.Errorf("request service error, %s", resp)
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: token info of %s", datasource.ErrNotFound, contract)
 	}
 
-	ethInfo := res.Result.(types.EtherTokenInfo)
+	ethInfo := infos[0]
 	tokenInfo := &types.TokenInfo{
 		Name:        ethInfo.TokenName,
 		Symbol:      ethInfo.Symbol,
@@ -68,18 +81,29 @@ func (e *ether) GetTokenInfo(contract string) (*types.TokenInfo, error) {
 		Discord:     ethInfo.Discord,
 		Github:      ethInfo.Github,
 		Description: ethInfo.Description,
+		TotalSupply: ethInfo.TotalSupply,
 	}
 	return tokenInfo, err
 }
 
 func (e *ether) GetSourceCode(contract string) ([]*types.EtherSourceCode, error) {
+	return e.GetSourceCodeCtx(context.Background(), contract)
+}
+
+// GetSourceCodeCtx returns the implementation's source with the merged ABI when proxy resolution is on.
+func (e *ether) GetSourceCodeCtx(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
+	if e.resolveProxy {
+		return e.resolvedSourceCode(ctx, contract)
+	}
+	return e.getSourceCode(ctx, contract)
+}
+
+func (e *ether) getSourceCode(ctx context.Context, contract string) ([]*types.EtherSourceCode, error) {
 	if !e.check() {
-		return nil, fmt.Errorf("config mismatched for %s", e.source)
+		return nil, e.misconfigured()
 	}
 
-	url := e.url + "module=contract&action=getsourcecode&address=" + contract + "&apiKey=" + e.apiKey
-	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET)
-	resp, err := net.Request()
+	resp, err := e.request(ctx, e.endpoint("contract", "getsourcecode", url.Values{"address": {contract}}))
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +115,7 @@ func (e *ether) GetSourceCode(contract string) ([]*types.EtherSourceCode, error)
 	}
 
 	if res.Status != "1" {
-		return nil, fmt.Errorf("request service error for %s scan", e.source)
+		return nil, e.serviceError(res)
 	}
 
 	var sourceCode []*types.EtherSourceCode
@@ -100,6 +124,7 @@ func (e *ether) GetSourceCode(contract string) ([]*types.EtherSourceCode, error)
 		if err = mapstructure.Decode(_codeRes, code); err != nil {
 			return nil, err
 		}
+		e.remember(code.ABI)
 
 		sourceCode = append(sourceCode, code)
 	}
@@ -107,26 +132,37 @@ func (e *ether) GetSourceCode(contract string) ([]*types.EtherSourceCode, error)
 }
 
 func (e *ether) GetABIData(contract string) (string, error) {
+	return e.GetABIDataCtx(context.Background(), contract)
+}
+
+// GetABIDataCtx returns the implementation's ABI merged with the proxy's when proxy resolution is on.
+func (e *ether) GetABIDataCtx(ctx context.Context, contract string) (string, error) {
+	if e.resolveProxy {
+		return e.resolvedABI(ctx, contract)
+	}
+	return e.getABI(ctx, contract)
+}
+
+func (e *ether) getABI(ctx context.Context, contract string) (string, error) {
 	if !e.check() {
-		return "", fmt.Errorf("config mismatched for %s", e.source)
+		return "", e.misconfigured()
 	}
 
-	abi, err := e.getAbiData(contract)
+	abi, err := e.getAbiData(ctx, contract)
 	if err != nil {
 		return "", err
 	}
 
 	if abi.Status != "1" {
-		return "", fmt.Errorf("request service error, %s", abi)
+		return "", e.serviceError(abi)
 	}
 
+	e.remember(abi.Result.(string))
 	return abi.Result.(string), nil
 }
 
-func (e *ether) getAbiData(contract string) (*types.EtherResult, error) {
-	url := e.url + "module=contract&action=getabi&address=" + contract + "&apiKey=" + e.apiKey
-	net := datasource.NewNet(url, req.Header{}, req.Param{}, datasource.GET)
-	resp, err := net.Request()
+func (e *ether) getAbiData(ctx context.Context, contract string) (*types.EtherResult, error) {
+	resp, err := e.request(ctx, e.endpoint("contract", "getabi", url.Values{"address": {contract}}))
 	if err != nil {
 		return nil, err
 	}
@@ -141,13 +177,25 @@ func (e *ether) getAbiData(contract string) (*types.EtherResult, error) {
 }
 
 func (e *ether) IsVerifyCode(contract string) (bool, error) {
+	return e.IsVerifyCodeCtx(context.Background(), contract)
+}
+
+func (e *ether) IsVerifyCodeCtx(ctx context.Context, contract string) (bool, error) {
 	if !e.check() {
-		return false, fmt.Errorf("config mismatched for %s", e.source)
+		return false, e.misconfigured()
 	}
 
-	abi, err := e.getAbiData(contract)
+	abi, err := e.getAbiData(ctx, contract)
 	if err != nil {
 		return false, err
 	}
-	return abi.Status == "1", nil
+
+	if abi.Status != "1" {
+		err = e.serviceError(abi)
+		if errors.Is(err, datasource.ErrNotVerified) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
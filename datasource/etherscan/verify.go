@@ -0,0 +1,198 @@
+package etherscan
+
+import (
+	"context"
+	"fmt"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"github.com/imroc/req"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// api document: https://docs.etherscan.io/tutorials/verifying-contracts-programmatically
+
+type CodeFormat string
+
+const (
+	SoliditySingleFile   CodeFormat = "solidity-single-file"
+	SolidityStandardJSON CodeFormat = "solidity-standard-json-input"
+	VyperJSON            CodeFormat = "vyper-json"
+)
+
+// MaxVerifyLibraries is the number of libraries verifysourcecode accepts.
+const MaxVerifyLibraries = 10
+
+const defaultPollInterval = 5 * time.Second
+
+// VerifyRequest is a verifysourcecode submission. SourceCode is the flattened
+// source for SoliditySingleFile and the standard json input otherwise. ContractName
+// is "Name" for a single file and "path/File.sol:Name" for json inputs.
+// CompilerVersion reads like v0.8.19+commit.7dd6d404, or vyper:0.3.10 for vyper.
+type VerifyRequest struct {
+	ContractAddress      string
+	SourceCode           string
+	CodeFormat           CodeFormat
+	ContractName         string
+	CompilerVersion      string
+	OptimizationUsed     bool
+	Runs                 int
+	ConstructorArguments string
+	EVMVersion           string
+	LicenseType          int
+	Libraries            map[string]string
+}
+
+func (v *VerifyRequest) values() (url.Values, error) {
+	if len(v.Libraries) > MaxVerifyLibraries {
+		return nil, fmt.Errorf("verifysourcecode accepts at most %d libraries, got %d", MaxVerifyLibraries, len(v.Libraries))
+	}
+
+	codeFormat := v.CodeFormat
+	if codeFormat == "" {
+		codeFormat = SoliditySingleFile
+	}
+
+	params := url.Values{}
+	params.Set("contractaddress", v.ContractAddress)
+	params.Set("sourceCode", v.SourceCode)
+	params.Set("codeformat", string(codeFormat))
+	params.Set("contractname", v.ContractName)
+	params.Set("compilerversion", v.CompilerVersion)
+	// etherscan spells it this way
+	params.Set("constructorArguements", strings.TrimPrefix(v.ConstructorArguments, "0x"))
+
+	if codeFormat == SoliditySingleFile {
+		params.Set("optimizationUsed", "0")
+		if v.OptimizationUsed {
+			params.Set("optimizationUsed", "1")
+		}
+		params.Set("runs", strconv.Itoa(v.Runs))
+		if v.EVMVersion != "" {
+			params.Set("evmversion", v.EVMVersion)
+		}
+	}
+
+	if v.LicenseType != 0 {
+		params.Set("licenseType", strconv.Itoa(v.LicenseType))
+	}
+
+	i := 1
+	for name, address := range v.Libraries {
+		params.Set("libraryname"+strconv.Itoa(i), name)
+		params.Set("libraryaddress"+strconv.Itoa(i), address)
+		i++
+	}
+	return params, nil
+}
+
+// VerifySourceCode submits a verification and returns the guid to poll it with.
+func (e *ether) VerifySourceCode(ctx context.Context, request *VerifyRequest) (string, error) {
+	params, err := request.values()
+	if err != nil {
+		return "", err
+	}
+
+	var guid string
+	err = e.post(ctx, "contract", "verifysourcecode", params, &guid)
+	return guid, err
+}
+
+func (e *ether) CheckVerifyStatus(ctx context.Context, guid string) (*types.EtherVerifyStatus, error) {
+	return e.verifyStatus(ctx, "checkverifystatus", guid)
+}
+
+// WaitForVerification polls CheckVerifyStatus every interval until the verification passes or fails.
+func (e *ether) WaitForVerification(ctx context.Context, guid string, interval time.Duration) (*types.EtherVerifyStatus, error) {
+	return e.waitFor(ctx, "checkverifystatus", guid, interval)
+}
+
+// VerifyProxyContract asks etherscan to link address to its implementation, expectedImplementation may be empty.
+func (e *ether) VerifyProxyContract(ctx context.Context, address, expectedImplementation string) (string, error) {
+	params := url.Values{"address": {address}}
+	if expectedImplementation != "" {
+		params.Set("expectedimplementation", expectedImplementation)
+	}
+
+	var guid string
+	err := e.post(ctx, "contract", "verifyproxycontract", params, &guid)
+	return guid, err
+}
+
+func (e *ether) CheckProxyVerification(ctx context.Context, guid string) (*types.EtherVerifyStatus, error) {
+	return e.verifyStatus(ctx, "checkproxyverification", guid)
+}
+
+// WaitForProxyVerification polls CheckProxyVerification every interval until the verification passes or fails.
+func (e *ether) WaitForProxyVerification(ctx context.Context, guid string, interval time.Duration) (*types.EtherVerifyStatus, error) {
+	return e.waitFor(ctx, "checkproxyverification", guid, interval)
+}
+
+func (e *ether) waitFor(ctx context.Context, action, guid string, interval time.Duration) (*types.EtherVerifyStatus, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := e.verifyStatus(ctx, action, guid)
+		if err != nil || status.State != types.VerifyPending {
+			return status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// verifyStatus reads pending and failed verifications out of NOTOK answers,
+// which are only errors when etherscan refused the call itself.
+func (e *ether) verifyStatus(ctx context.Context, action, guid string) (*types.EtherVerifyStatus, error) {
+	if !e.check() {
+		return nil, e.misconfigured()
+	}
+
+	net := datasource.NewNet(e.endpoint("contract", action, url.Values{"guid": {guid}}), req.Header{}, req.Param{}, datasource.GET)
+	res, err := e.result(ctx, net)
+	if err != nil {
+		return nil, err
+	}
+
+	message, _ := res.Result.(string)
+	if message == "" {
+		message = res.Message
+	}
+
+	if state, ok := verifyState(res.Status, message); ok {
+		return &types.EtherVerifyStatus{State: state, Message: message}, nil
+	}
+
+	if sentinel := classify(message); sentinel == datasource.ErrRateLimited || sentinel == datasource.ErrInvalidAPIKey {
+		return nil, e.serviceError(res)
+	}
+	return &types.EtherVerifyStatus{State: types.VerifyFail, Message: message}, nil
+}
+
+// verifyState matches message against the prefixes etherscan documents for
+// verification statuses, failures first as their free text may mention anything.
+// It reports false for answers that are none of them.
+func verifyState(status, message string) (types.VerifyState, bool) {
+	lower := strings.ToLower(strings.TrimSpace(message))
+	switch {
+	case strings.HasPrefix(lower, "fail - unable to verify"):
+		return types.VerifyFail, true
+	case status == "1", strings.HasPrefix(lower, "pass - verified"), strings.HasPrefix(lower, "already verified"):
+		return types.VerifyPass, true
+	case strings.HasPrefix(lower, "pending in queue"):
+		return types.VerifyPending, true
+	default:
+		return "", false
+	}
+}
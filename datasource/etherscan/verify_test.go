@@ -0,0 +1,93 @@
+package etherscan
+
+import (
+	"context"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestVerifySourceCode(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %s", err)
+		}
+
+		switch r.Form.Get("action") {
+		case "verifysourcecode":
+			if r.Method != http.MethodPost || r.PostForm.Get("codeformat") != string(SolidityStandardJSON) ||
+				r.PostForm.Get("constructorArguements") != "00ff" || r.PostForm.Get("libraryname1") != "Math" {
+				t.Errorf("unexpected submission: %s %v", r.Method, r.PostForm)
+			}
+			_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"guid-1"}`))
+		case "checkverifystatus":
+			polls++
+			if polls < 2 {
+				_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Pending in queue"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"Pass - Verified"}`))
+		}
+	}))
+	defer srv.Close()
+
+	// polls must not be answered from the cache
+	e := NewEther("etherscan", srv.URL, "key", &datasource.Requester{Cache: datasource.NewMemoryCache(0)})
+	guid, err := e.VerifySourceCode(context.Background(), &VerifyRequest{
+		ContractAddress:      "0xa",
+		SourceCode:           `{"language":"Solidity"}`,
+		CodeFormat:           SolidityStandardJSON,
+		ContractName:         "contracts/Token.sol:Token",
+		CompilerVersion:      "v0.8.19+commit.7dd6d404",
+		ConstructorArguments: "0x00ff",
+		Libraries:            map[string]string{"Math": "0xb"},
+	})
+	if err != nil || guid != "guid-1" {
+		t.Fatalf("unexpected guid: %s, %v", guid, err)
+	}
+
+	status, err := e.WaitForVerification(context.Background(), guid, time.Millisecond)
+	if err != nil || status.State != types.VerifyPass || polls != 2 {
+		t.Fatalf("unexpected status: %+v, %v after %d polls", status, err, polls)
+	}
+}
+
+func TestCheckVerifyStatusFail(t *testing.T) {
+	e := newTestEther(t, func(query url.Values) string {
+		return `{"status":"0","message":"NOTOK","result":"Fail - Unable to verify"}`
+	})
+
+	status, err := e.CheckVerifyStatus(context.Background(), "guid-1")
+	if err != nil || status.State != types.VerifyFail {
+		t.Fatalf("unexpected status: %+v, %v", status, err)
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	tests := []struct {
+		status  string
+		message string
+		want    types.VerifyState
+		ok      bool
+	}{
+		{"1", "Pass - Verified", types.VerifyPass, true},
+		{"0", "Already Verified", types.VerifyPass, true},
+		{"0", "Pending in queue", types.VerifyPending, true},
+		{"0", "Fail - Unable to verify", types.VerifyFail, true},
+		{"0", "Fail - Unable to verify. Solidity compilation bypass detected", types.VerifyFail, true},
+		{"0", "Fail - Unable to verify, pending library link", types.VerifyFail, true},
+		{"0", "Invalid password", "", false},
+		{"0", "Max rate limit reached", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := verifyState(tt.status, tt.message); got != tt.want || ok != tt.ok {
+			t.Errorf("%q: got %q, %v, want %q, %v", tt.message, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,11 +1,26 @@
 package datasource
 
-import "github.com/ThreeAndTwo/chainscan-api/types"
+import (
+	"context"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+)
 
 type IDataSource interface {
+	IDataSourceCtx
+
 	GetMarketInfoForCoin() ([]*types.MarketInfo, error)
 	GetTokenInfo(string) (*types.TokenInfo, error)
 	GetSourceCode(string) ([]*types.EtherSourceCode, error)
 	GetABIData(string) (string, error)
 	IsVerifyCode(string) (bool, error)
 }
+
+// IDataSourceCtx is the context-aware variant of IDataSource. The context bounds
+// the underlying HTTP call, so a cancelled or expired ctx aborts the lookup.
+type IDataSourceCtx interface {
+	GetMarketInfoForCoinCtx(context.Context) ([]*types.MarketInfo, error)
+	GetTokenInfoCtx(context.Context, string) (*types.TokenInfo, error)
+	GetSourceCodeCtx(context.Context, string) ([]*types.EtherSourceCode, error)
+	GetABIDataCtx(context.Context, string) (string, error)
+	IsVerifyCodeCtx(context.Context, string) (bool, error)
+}
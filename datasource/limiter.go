@@ -0,0 +1,57 @@
+package datasource
+
+import (
+	"golang.org/x/time/rate"
+	"sync"
+)
+
+type LimitMode int
+
+const (
+	// Block waits for the limiter until the request context is done.
+	Block LimitMode = iota
+	// FailFast returns ErrRateLimited instead of waiting when no token is available.
+	FailFast
+)
+
+var (
+	sharedLimiters   = make(map[string]*rate.Limiter)
+	sharedLimitersMu sync.Mutex
+)
+
+// NewLimiter allows tps requests per second without bursting: requests are spaced
+// 1/tps apart. This differs from the limiter NewDataSource used to build, which
+// refilled one token per second into a burst of tps.
+func NewLimiter(tps int) *rate.Limiter {
+	if tps <= 0 {
+		tps = 1
+	}
+	return rate.NewLimiter(rate.Limit(tps), 1)
+}
+
+// SharedLimiter returns the limiter registered for key, so every source using
+// the same API key draws from one budget. The first caller's tps wins, later
+// callers get that limiter whatever tps they ask for. Limiters stay registered
+// for the life of the process unless released with ReleaseSharedLimiter.
+func SharedLimiter(key string, tps int) *rate.Limiter {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+
+	if limiter, ok := sharedLimiters[key]; ok {
+		return limiter
+	}
+
+	limiter := NewLimiter(tps)
+	sharedLimiters[key] = limiter
+	return limiter
+}
+
+// ReleaseSharedLimiter forgets the limiter registered for key, for processes
+// cycling through API keys. Sources already holding it keep using it, the next
+// SharedLimiter call for key starts a new budget.
+func ReleaseSharedLimiter(key string) {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+
+	delete(sharedLimiters, key)
+}
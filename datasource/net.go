@@ -1,8 +1,10 @@
 package datasource
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/imroc/req"
+	"net/http"
 	"strings"
 )
 
@@ -19,6 +21,9 @@ type Net struct {
 	param   req.Param
 	reqType ReqType
 	isJson  bool
+	retryIf func([]byte) bool
+	cacheIf func([]byte) bool
+	client  *http.Client
 }
 
 func NewNet(url string, header req.Header, param req.Param, reqType ReqType) *Net {
@@ -51,32 +56,65 @@ func InitParam(params map[string]interface{}) req.Param {
 }
 
 func (n *Net) Request() ([]byte, error) {
+	return n.RequestCtx(context.Background())
+}
+
+// RequestCtx sends the request bound to ctx, it returns ctx.Err() once ctx is done.
+func (n *Net) RequestCtx(ctx context.Context) ([]byte, error) {
+	resp, err := n.send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Bytes(), nil
+}
+
+// SetRetryIf registers the check telling a Requester that a response body asks for a retry,
+// e.g. a rate limit answered with HTTP 200.
+func (n *Net) SetRetryIf(retryIf func([]byte) bool) *Net {
+	n.retryIf = retryIf
+	return n
+}
+
+// SetCacheIf lets a Requester cache the response bodies cacheIf accepts, which
+// should only be successful answers. Requests without it bypass the cache.
+func (n *Net) SetCacheIf(cacheIf func([]byte) bool) *Net {
+	n.cacheIf = cacheIf
+	return n
+}
+
+// SetClient sends the request through client instead of imroc/req's default client.
+func (n *Net) SetClient(client *http.Client) *Net {
+	n.client = client
+	return n
+}
+
+func (n *Net) send(ctx context.Context) (*req.Resp, error) {
 	switch n.reqType {
 	case POST:
-		return n.post()
+		return n.post(ctx)
 	case GET:
-		return n.get()
+		return n.get(ctx)
 	default:
-		return n.get()
+		return n.get(ctx)
 	}
 }
 
-func (n *Net) post() ([]byte, error) {
-	var reqResp = &req.Resp{}
-	var err error
+func (n *Net) post(ctx context.Context) (*req.Resp, error) {
 	if n.isJson {
 		jsonParam, _ := json.Marshal(n.param)
-		reqResp, err = req.Post(n.url, jsonParam, n.header)
-	} else {
-		reqResp, err = req.Post(n.url, n.param, n.header)
+		return req.Post(n.url, jsonParam, n.header, ctx, n.options())
 	}
-	return reqResp.Bytes(), err
+	return req.Post(n.url, n.param, n.header, ctx, n.options())
 }
 
-func (n *Net) get() ([]byte, error) {
-	resp, err := req.Get(n.url, n.header)
-	if err != nil {
-		return nil, err
+func (n *Net) get(ctx context.Context) (*req.Resp, error) {
+	return req.Get(n.url, n.header, ctx, n.options())
+}
+
+// options are the optional arguments handed to imroc/req, which ignores a nil interface.
+func (n *Net) options() interface{} {
+	if n.client == nil {
+		return nil
 	}
-	return resp.Bytes(), nil
+	return n.client
 }
@@ -0,0 +1,181 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/imroc/req"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Requester sends Net requests on behalf of a source, gating each attempt on the
+// source's rate limiter and retrying according to Retry. A nil Requester or
+// Limiter sends requests unthrottled, a nil Retry sends them once.
+type Requester struct {
+	Limiter *rate.Limiter
+	Mode    LimitMode
+	Retry   *RetryPolicy
+	// Client carries timeouts, proxies, TLS config or a custom RoundTripper for
+	// every request, nil falls back to imroc/req's default client.
+	Client *http.Client
+	// Cache answers GET requests it already holds a successful response for, only
+	// requests whose Net has a cacheIf check go through it.
+	Cache  Cache
+	Logger Logger
+}
+
+func NewRequester(limiter *rate.Limiter, mode LimitMode) *Requester {
+	return &Requester{Limiter: limiter, Mode: mode}
+}
+
+// Wait takes one token from the limiter according to Mode.
+func (r *Requester) Wait(ctx context.Context) error {
+	if r == nil || r.Limiter == nil {
+		return nil
+	}
+
+	if r.Mode == FailFast {
+		if !r.Limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+	return r.Limiter.Wait(ctx)
+}
+
+// SetTransport routes every request through transport.
+func (r *Requester) SetTransport(transport http.RoundTripper) *Requester {
+	r.Client = &http.Client{Transport: transport}
+	return r
+}
+
+func (r *Requester) Do(ctx context.Context, net *Net) ([]byte, error) {
+	if r == nil {
+		r = &Requester{}
+	}
+
+	if net.client == nil {
+		net.client = r.Client
+	}
+
+	key := ""
+	if r.Cache != nil && net.reqType != POST && net.cacheIf != nil {
+		key = net.url
+		if body, ok := r.Cache.Get(key); ok {
+			r.logf("cache hit %s", redact(net.url))
+			return body, nil
+		}
+	}
+
+	body, statusCode, err := r.do(ctx, net)
+	if err != nil {
+		return nil, err
+	}
+
+	if key != "" && statusCode/100 == 2 && net.cacheIf(body) && (net.retryIf == nil || !net.retryIf(body)) {
+		r.Cache.Set(key, body)
+	}
+	return body, nil
+}
+
+func (r *Requester) do(ctx context.Context, net *Net) ([]byte, int, error) {
+	for attempt := 1; ; attempt++ {
+		if err := r.Wait(ctx); err != nil {
+			return nil, 0, err
+		}
+
+		start := time.Now()
+		resp, err := net.send(ctx)
+		event := RetryEvent{URL: redact(net.url), Attempt: attempt, Err: err}
+		if err == nil {
+			if httpResp := resp.Response(); httpResp != nil {
+				event.StatusCode = httpResp.StatusCode
+				event.Delay = retryAfter(httpResp.Header)
+			}
+		}
+		r.logf("%s %s: status %d in %s, err: %v", net.reqType, event.URL, event.StatusCode, time.Since(start), err)
+
+		event.Err = retryReason(ctx, net, resp, event)
+		if attempt >= r.Retry.attempts() || event.Err == nil {
+			if err != nil {
+				return nil, 0, err
+			}
+			// retries are exhausted, the answer is still a failure
+			if event.Err != nil {
+				return nil, event.StatusCode, fmt.Errorf("http status %d after %d attempts: %w", event.StatusCode, attempt, event.Err)
+			}
+			return resp.Bytes(), event.StatusCode, nil
+		}
+
+		if backoff := r.Retry.backoff(attempt); backoff > event.Delay {
+			event.Delay = backoff
+		}
+		r.logf("retry %s after attempt %d in %s: %v", event.URL, attempt, event.Delay, event.Err)
+		if r.Retry.OnRetry != nil {
+			r.Retry.OnRetry(event)
+		}
+
+		timer := time.NewTimer(event.Delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Requester) logf(format string, v ...interface{}) {
+	if r.Logger != nil {
+		r.Logger.Printf(format, v...)
+	}
+}
+
+// retryReason returns why the attempt should be retried, or nil when it should not.
+// A POST may have reached the server before failing, e.g. submitting a verification,
+// so it is only sent again when the server explicitly asks for a retry.
+func retryReason(ctx context.Context, net *Net, resp *req.Resp, event RetryEvent) error {
+	switch {
+	case ctx.Err() != nil:
+		return nil
+	case event.Err != nil:
+		if net.reqType == POST {
+			return nil
+		}
+		return event.Err
+	case event.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case net.retryIf != nil && net.retryIf(resp.Bytes()):
+		return ErrRateLimited
+	case net.reqType == POST:
+		if httpResp := resp.Response(); httpResp != nil && httpResp.Header.Get("Retry-After") != "" {
+			return errors.New("retry-after requested")
+		}
+		return nil
+	case retryableStatus(event.StatusCode):
+		return errors.New(strings.ToLower(http.StatusText(event.StatusCode)))
+	default:
+		return nil
+	}
+}
+
+// redact drops the api key etherscan style sources put in the query string.
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.EqualFold(key, "apikey") {
+			query.Set(key, "***")
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,195 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"github.com/imroc/req"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequesterFailFast(t *testing.T) {
+	r := NewRequester(NewLimiter(1), FailFast)
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("first token: %s", err)
+	}
+	if err := r.Wait(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got: %v", err)
+	}
+}
+
+func TestSharedLimiter(t *testing.T) {
+	if SharedLimiter("etherscan:key", 5) != SharedLimiter("etherscan:key", 1) {
+		t.Fatal("expected the same limiter for the same key")
+	}
+	if SharedLimiter("etherscan:key", 5) == SharedLimiter("etherscan:other", 5) {
+		t.Fatal("expected distinct limiters for distinct keys")
+	}
+
+	limiter := SharedLimiter("etherscan:key", 5)
+	ReleaseSharedLimiter("etherscan:key")
+	if SharedLimiter("etherscan:key", 5) == limiter {
+		t.Fatal("expected a released key to get a new limiter")
+	}
+}
+
+func TestRequesterRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			_, _ = w.Write([]byte(`busy`))
+		default:
+			_, _ = w.Write([]byte(`ok`))
+		}
+	}))
+	defer srv.Close()
+
+	var events []RetryEvent
+	r := &Requester{Retry: &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		OnRetry:     func(event RetryEvent) { events = append(events, event) },
+	}}
+
+	net := NewNet(srv.URL, req.Header{}, req.Param{}, GET).SetRetryIf(func(body []byte) bool {
+		return string(body) == "busy"
+	})
+	body, err := r.Do(context.Background(), net)
+	if err != nil || string(body) != "ok" {
+		t.Fatalf("unexpected result: %s, %v", body, err)
+	}
+
+	if len(events) != 2 || events[0].StatusCode != http.StatusTooManyRequests || !errors.Is(events[1].Err, ErrRateLimited) {
+		t.Fatalf("unexpected retry events: %+v", events)
+	}
+}
+
+func TestRequesterRetriesExhausted(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"slow down"}`))
+	}))
+	defer srv.Close()
+
+	r := &Requester{Retry: &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}}
+	body, err := r.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, GET))
+	if !errors.Is(err, ErrRateLimited) || body != nil {
+		t.Fatalf("expected ErrRateLimited, got: %s, %v", body, err)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected every attempt to be used, got %d calls", calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	if delay := retryAfter(header); delay != 3*time.Second {
+		t.Fatalf("unexpected delay: %s", delay)
+	}
+}
+
+type countingTransport struct {
+	calls int32
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.calls, 1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestRequesterTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	defer srv.Close()
+
+	transport := &countingTransport{}
+	r := (&Requester{}).SetTransport(transport)
+	if _, err := r.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, GET)); err != nil {
+		t.Fatalf("request: %s", err)
+	}
+
+	if atomic.LoadInt32(&transport.calls) != 1 {
+		t.Fatalf("expected the request to go through the transport, got %d calls", transport.calls)
+	}
+}
+
+func TestRequesterCacheIf(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			_, _ = w.Write([]byte(`error`))
+			return
+		}
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	defer srv.Close()
+
+	r := &Requester{Cache: NewMemoryCache(0)}
+	isOK := func(body []byte) bool { return string(body) == "ok" }
+	for _, want := range []string{"error", "ok", "ok"} {
+		body, err := r.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, GET).SetCacheIf(isOK))
+		if err != nil || string(body) != want {
+			t.Fatalf("unexpected result: %s, %v, want %s", body, err, want)
+		}
+	}
+
+	if _, err := r.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, GET)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("expected only the accepted answer to be cached and uncheckable requests to bypass it, got %d calls", calls)
+	}
+}
+
+func TestRequesterRetriesPostOnlyWhenAsked(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`ok`))
+		}
+	}))
+	defer srv.Close()
+
+	r := &Requester{Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+	if _, err := r.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, POST)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected a retry for retry-after only, got %d calls", calls)
+	}
+
+	timeout := &Requester{Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+	timeout.SetTransport(&failingTransport{})
+	if _, err := timeout.Do(context.Background(), NewNet(srv.URL, req.Header{}, req.Param{}, POST)); err == nil {
+		t.Fatal("expected the transport error")
+	}
+	if n := timeout.Client.Transport.(*failingTransport).calls; n != 1 {
+		t.Fatalf("expected a failed POST to be sent once, got %d", n)
+	}
+}
+
+type failingTransport struct {
+	calls int
+}
+
+func (f *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.calls++
+	return nil, errors.New("connection reset")
+}
@@ -0,0 +1,90 @@
+package datasource
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries requests answered with HTTP 429/502/503/504, failed on the
+// transport, or whose body is flagged by Net.SetRetryIf. POSTs are only retried
+// on HTTP 429, a Retry-After header or a flagged body. Once attempts run out such
+// an answer is an error, wrapping ErrRateLimited for HTTP 429 and flagged bodies.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, values below 2 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter randomises each delay by up to this fraction of it, in [0, 1].
+	Jitter float64
+	// OnRetry is called before sleeping ahead of every retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes the failed attempt a retry is scheduled for.
+type RetryEvent struct {
+	URL        string
+	Attempt    int
+	StatusCode int
+	Delay      time.Duration
+	Err        error
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff is BaseDelay doubled per attempt, capped at MaxDelay, then jittered.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(delay)
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(at); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -2,9 +2,11 @@ module github.com/ThreeAndTwo/chainscan-api
 
 go 1.17
 
-require golang.org/x/time v0.0.0-20220411224347-583f2d630306
-
 require (
-	github.com/imroc/req v0.3.2 // indirect
-	github.com/mitchellh/mapstructure v1.4.3 // indirect
+	github.com/imroc/req v0.3.2
+	github.com/mitchellh/mapstructure v1.4.3
+	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e
+	golang.org/x/time v0.0.0-20220411224347-583f2d630306
 )
+
+require golang.org/x/sys v0.10.0 // indirect
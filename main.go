@@ -7,36 +7,71 @@ import (
 	"github.com/ThreeAndTwo/chainscan-api/datasource/coinmarketcap"
 	"github.com/ThreeAndTwo/chainscan-api/datasource/etherscan"
 	"github.com/ThreeAndTwo/chainscan-api/types"
-	"golang.org/x/time/rate"
 	"time"
 )
 
+// NewDataSource blocks on a limiter shared by every source with the same platform and API key,
+// and retries with datasource.DefaultRetryPolicy.
 func NewDataSource(source string, alias types.PlatformForDataSource, url, apiKey string, tps int) (datasource.IDataSource, error) {
-	platform := types.PlatformForDataSource("")
-	if alias == "" {
-		platform = types.PlatformForDataSource(source)
-	} else {
-		platform = alias
-	}
+	return NewDataSourceWithOptions(platformFor(source, alias), WithSource(source), WithBaseURL(url), WithAPIKey(apiKey), WithTPS(tps))
+}
+
+func NewDataSourceWithOptions(platform types.PlatformForDataSource, opts ...Option) (datasource.IDataSource, error) {
+	cfg := newConfig(platform, opts...)
+	requester := cfg.newRequester(platform)
 
-	if tps <= 0 {
-		tps = 1
+	marketMap := cfg.marketMap
+	if marketMap == nil {
+		marketMap = &types.MarketMap{
+			Market:        make(map[string]map[string]*types.MarketInfo),
+			LastUpdatedAt: time.Now(),
+		}
 	}
 
-	marketMap := &types.MarketMap{
-		Market:        make(map[string]map[string]*types.MarketInfo),
-		LastUpdatedAt: time.Now(),
+	var chain *types.Chain
+	if cfg.chainID != 0 {
+		var ok bool
+		if chain, ok = types.ChainByID(cfg.chainID); !ok && platform != types.EtherScan {
+			return nil, fmt.Errorf("%w: unknown chain %d for %s source", datasource.ErrMisconfigured, cfg.chainID, cfg.source)
+		}
 	}
-	rateLimiter := rate.NewLimiter(rate.Every(time.Second*1), tps)
 
 	switch platform {
 	case types.EtherScan:
-		return etherscan.NewEther(source, url, apiKey, rateLimiter), nil
+		url := cfg.url
+		if url == "" && cfg.chainID != 0 {
+			url = etherscan.V2URL
+		}
+		if named, ok := types.ChainByName(cfg.source); url == "" && ok {
+			url = named.ExplorerAPI
+		}
+		if url == "" {
+			return nil, fmt.Errorf("%w: base url required for %s source", datasource.ErrMisconfigured, cfg.source)
+		}
+		return etherscan.NewEther(cfg.source, url, cfg.apiKey, requester).
+			SetProxyResolution(cfg.resolveProxy).
+			SetSignatureDB(cfg.signatures).
+			SetChainID(cfg.chainID), nil
 	case types.CoinMarketCap:
-		return coinmarketcap.NewCmc(source, url, apiKey, rateLimiter, marketMap), nil
+		cmc := coinmarketcap.NewCmc(cfg.source, cfg.url, cfg.apiKey, requester, marketMap)
+		if chain != nil {
+			cmc.SetPlatform(chain.CMCPlatform)
+		}
+		return cmc, nil
 	case types.CoinGecko:
-		return coingecko.NewCoinGecko(source, url, apiKey, rateLimiter, marketMap), nil
+		coinGecko := coingecko.NewCoinGecko(cfg.source, cfg.url, cfg.apiKey, requester, marketMap)
+		if chain != nil {
+			coinGecko.SetPlatform(chain.CoinGeckoPlatform)
+		}
+		return coinGecko, nil
 	default:
-		return nil, fmt.Errorf("unknown datasource for %s source. plz check it", source)
+		return nil, fmt.Errorf("unknown datasource for %s source. plz check it", cfg.source)
+	}
+}
+
+func platformFor(source string, alias types.PlatformForDataSource) types.PlatformForDataSource {
+	if alias == "" {
+		return types.PlatformForDataSource(source)
 	}
+	return alias
 }
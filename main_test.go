@@ -1,10 +1,17 @@
 package chainscan_api
 
 import (
+	"errors"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
 	"github.com/ThreeAndTwo/chainscan-api/types"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
-	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNewDataSource(t *testing.T) {
@@ -61,27 +68,27 @@ func TestNewDataSource(t *testing.T) {
 			}
 
 			coin, err := source.GetMarketInfoForCoin()
-			if err != nil && !strings.Contains(err.Error(), "unSupport") {
+			if err != nil && !errors.Is(err, datasource.ErrUnsupported) {
 				t.Fatalf("unknown error: %s, for %s platform", err, tt.name)
 			}
 
 			t.Logf("coin: %v", coin)
 
 			sourceCode, err := source.GetSourceCode(tt.contract)
-			if err != nil && !strings.Contains(err.Error(), "unSupport") {
+			if err != nil && !errors.Is(err, datasource.ErrUnsupported) {
 				t.Fatalf("get source code error: %s, for %s platform", err, tt.name)
 			}
 
 			t.Logf("sourceCode: %v", sourceCode)
 
 			abiData, err := source.GetABIData(tt.contract)
-			if err != nil && !strings.Contains(err.Error(), "unSupport") {
+			if err != nil && !errors.Is(err, datasource.ErrUnsupported) {
 				t.Fatalf("get abi data error: %s, for %s platform", err, tt.name)
 			}
 			t.Logf("AbiData: %s", abiData)
 
 			IsVerified, err := source.IsVerifyCode(tt.contract)
-			if err != nil && !strings.Contains(err.Error(), "unSupport") {
+			if err != nil && !errors.Is(err, datasource.ErrUnsupported) {
 				t.Fatalf("get IsVerifyCode error: %s, for %s platform", err, tt.name)
 			}
 
@@ -95,3 +102,92 @@ func TestNewDataSource(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDataSourceWithOptions(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"[]"}`))
+	}))
+	defer srv.Close()
+
+	source, err := NewDataSourceWithOptions(types.EtherScan,
+		WithSource("etherscan"),
+		WithBaseURL(srv.URL),
+		WithAPIKey("options-key"),
+		WithTPS(100),
+		WithHTTPClient(srv.Client()),
+		WithCache(datasource.NewMemoryCache(time.Minute)),
+		WithLogger(log.New(io.Discard, "", 0)),
+	)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		abi, err := source.GetABIData("0xAf5191B0De278C7286d6C7CC6ab6BB8A73bA2Cd6")
+		if err != nil || abi != "[]" {
+			t.Fatalf("unexpected abi: %s, %v", abi, err)
+		}
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected the second lookup to hit the cache, got %d calls", calls)
+	}
+
+	requester := datasource.NewRequester(datasource.SharedLimiter("requester-key", 100), datasource.Block)
+	requester.Client = srv.Client()
+	requester.Cache = datasource.NewMemoryCache(time.Minute)
+	source, err = NewDataSourceWithOptions(types.EtherScan, WithBaseURL(srv.URL), WithAPIKey("requester-key"), WithRequester(requester))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = source.GetABIData("0xAf5191B0De278C7286d6C7CC6ab6BB8A73bA2Cd6"); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected the requester's cache to be used, got %d calls", calls)
+	}
+
+	if _, err = NewDataSourceWithOptions(types.EtherScan); !errors.Is(err, datasource.ErrMisconfigured) {
+		t.Fatalf("expected ErrMisconfigured without base url, got: %v", err)
+	}
+}
+
+func TestNewDataSourceForChain(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"id":"cake","symbol":"cake","name":"PancakeSwap"}`))
+	}))
+	defer srv.Close()
+
+	source, err := NewDataSourceWithOptions(types.CoinGecko, WithBaseURL(srv.URL+"/"), WithChainID(56))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	info, err := source.GetTokenInfo("0x0E09FaBB73Bd3Ade0a17ECC321fD13a19e81cE82")
+	if err != nil || info.Name != "PancakeSwap" {
+		t.Fatalf("unexpected token info: %+v, %v", info, err)
+	}
+	if path != "/coins/binance-smart-chain/contract/0x0e09fabb73bd3ade0a17ecc321fd13a19e81ce82" {
+		t.Fatalf("unexpected path: %s", path)
+	}
+
+	if _, err = NewDataSourceWithOptions(types.EtherScan, WithSource("polygon")); err != nil {
+		t.Fatalf("expected the explorer url of polygon to be resolved, got: %v", err)
+	}
+
+	if _, err = NewDataSourceWithOptions(types.CoinGecko, WithChainID(999999999)); !errors.Is(err, datasource.ErrMisconfigured) {
+		t.Fatalf("expected ErrMisconfigured for an unknown chain, got: %v", err)
+	}
+
+	chain, ok := types.ChainByName("BNB")
+	if !ok || chain.ID != 56 || chain.NativeCurrency.Symbol != "BNB" {
+		t.Fatalf("unexpected chain: %+v", chain)
+	}
+}
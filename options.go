@@ -0,0 +1,162 @@
+package chainscan_api
+
+import (
+	"github.com/ThreeAndTwo/chainscan-api/abi"
+	"github.com/ThreeAndTwo/chainscan-api/datasource"
+	"github.com/ThreeAndTwo/chainscan-api/types"
+	"net/http"
+)
+
+type Option func(*config)
+
+type config struct {
+	source    string
+	url       string
+	apiKey    string
+	tps       int
+	limitMode datasource.LimitMode
+	retry     *datasource.RetryPolicy
+	client    *http.Client
+	marketMap *types.MarketMap
+	cache     datasource.Cache
+	logger    datasource.Logger
+	requester *datasource.Requester
+
+	resolveProxy bool
+	signatures   *abi.SignatureDB
+	chainID      uint64
+}
+
+func newConfig(platform types.PlatformForDataSource, opts ...Option) *config {
+	cfg := &config{
+		source: string(platform),
+		tps:    1,
+		retry:  datasource.DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithSource names the explorer or chain the source serves, e.g. "bsc", it defaults to the platform.
+func WithSource(source string) Option {
+	return func(c *config) {
+		c.source = source
+	}
+}
+
+func WithAPIKey(apiKey string) Option {
+	return func(c *config) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBaseURL overrides the api endpoint, it is required for etherscan family explorers.
+func WithBaseURL(url string) Option {
+	return func(c *config) {
+		c.url = url
+	}
+}
+
+// WithTPS sets the requests per second allowed to every source sharing the API
+// key, spaced evenly without bursts. Sources share the limiter of the first one
+// built for the platform and key, a different tps later on is ignored, use
+// WithRequester to give a source a limiter of its own.
+func WithTPS(tps int) Option {
+	return func(c *config) {
+		c.tps = tps
+	}
+}
+
+func WithLimitMode(mode datasource.LimitMode) Option {
+	return func(c *config) {
+		c.limitMode = mode
+	}
+}
+
+// WithRetryPolicy replaces datasource.DefaultRetryPolicy, nil disables retries.
+func WithRetryPolicy(policy *datasource.RetryPolicy) Option {
+	return func(c *config) {
+		c.retry = policy
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *config) {
+		c.client = &http.Client{Transport: transport}
+	}
+}
+
+// WithMarketMap shares the market ids coingecko and coinmarketcap sources look tokens up with.
+func WithMarketMap(marketMap *types.MarketMap) Option {
+	return func(c *config) {
+		c.marketMap = marketMap
+	}
+}
+
+func WithCache(cache datasource.Cache) Option {
+	return func(c *config) {
+		c.cache = cache
+	}
+}
+
+func WithLogger(logger datasource.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithProxyResolution makes etherscan sources answer GetSourceCode and GetABIData
+// for the implementation behind a proxy.
+func WithProxyResolution() Option {
+	return func(c *config) {
+		c.resolveProxy = true
+	}
+}
+
+// WithSignatureDB records the signatures of every ABI etherscan sources fetch in
+// db and decodes calls and logs of unverified contracts through it.
+func WithSignatureDB(db *abi.SignatureDB) Option {
+	return func(c *config) {
+		c.signatures = db
+	}
+}
+
+// WithChainID selects the chain every source answers for: etherscan sources go
+// through the Etherscan V2 api, which is the base url unless WithBaseURL sets
+// another, CoinGecko and CoinMarketCap use the chain's platform from the registry.
+func WithChainID(chainID uint64) Option {
+	return func(c *config) {
+		c.chainID = chainID
+	}
+}
+
+// WithRequester lets callers bring their own limiter, LimitMode and RetryPolicy.
+// The requester replaces the one built from WithTPS, WithLimitMode,
+// WithRetryPolicy, WithHTTPClient, WithCache and WithLogger, which it ignores.
+func WithRequester(requester *datasource.Requester) Option {
+	return func(c *config) {
+		c.requester = requester
+	}
+}
+
+func (c *config) newRequester(platform types.PlatformForDataSource) *datasource.Requester {
+	if c.requester != nil {
+		return c.requester
+	}
+
+	requester := datasource.NewRequester(datasource.SharedLimiter(string(platform)+":"+c.apiKey, c.tps), c.limitMode)
+	requester.Retry = c.retry
+	requester.Client = c.client
+	requester.Cache = c.cache
+	requester.Logger = c.logger
+	return requester
+}
@@ -0,0 +1,86 @@
+package types
+
+import "strings"
+
+// ABIArgument is an input or output of an ABIEntry, Components are set for tuples.
+type ABIArgument struct {
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	InternalType string        `json:"internalType,omitempty"`
+	Indexed      bool          `json:"indexed,omitempty"`
+	Components   []ABIArgument `json:"components,omitempty"`
+}
+
+// ABIEntry is one element of a contract ABI json, Type is one of function,
+// event, error, constructor, fallback or receive. Signature, Selector and Topic
+// are derived locally by abi.Parse: Selector is set for functions and errors,
+// Topic for events, both 0x prefixed hex.
+type ABIEntry struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name,omitempty"`
+	Inputs          []ABIArgument `json:"inputs,omitempty"`
+	Outputs         []ABIArgument `json:"outputs,omitempty"`
+	StateMutability string        `json:"stateMutability,omitempty"`
+	Anonymous       bool          `json:"anonymous,omitempty"`
+	Constant        bool          `json:"constant,omitempty"`
+	Payable         bool          `json:"payable,omitempty"`
+
+	Signature string `json:"-"`
+	Selector  string `json:"-"`
+	Topic     string `json:"-"`
+}
+
+// ABI groups the entries of a contract ABI by type.
+type ABI struct {
+	Constructor *ABIEntry  `json:"constructor,omitempty"`
+	Fallback    *ABIEntry  `json:"fallback,omitempty"`
+	Receive     *ABIEntry  `json:"receive,omitempty"`
+	Functions   []ABIEntry `json:"functions"`
+	Events      []ABIEntry `json:"events"`
+	Errors      []ABIEntry `json:"errors"`
+}
+
+// FunctionBySelector looks a function up by its 0x prefixed 4 bytes selector.
+func (a *ABI) FunctionBySelector(selector string) *ABIEntry {
+	return findEntry(a.Functions, selector, func(entry *ABIEntry) string { return entry.Selector })
+}
+
+func (a *ABI) ErrorBySelector(selector string) *ABIEntry {
+	return findEntry(a.Errors, selector, func(entry *ABIEntry) string { return entry.Selector })
+}
+
+// EventByTopic looks an event up by its 0x prefixed topic hash.
+func (a *ABI) EventByTopic(topic string) *ABIEntry {
+	return findEntry(a.Events, topic, func(entry *ABIEntry) string { return entry.Topic })
+}
+
+func findEntry(entries []ABIEntry, key string, field func(*ABIEntry) string) *ABIEntry {
+	for i := range entries {
+		if strings.EqualFold(field(&entries[i]), key) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// DecodedValue is a decoded ABI value: *big.Int for integers, bool, abi.Address,
+// []byte for bytes and bytesN, string, []interface{} for arrays and
+// []DecodedValue for tuples.
+type DecodedValue struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type DecodedEvent struct {
+	Name      string         `json:"name"`
+	Signature string         `json:"signature"`
+	Args      []DecodedValue `json:"args"`
+}
+
+type DecodedCall struct {
+	Name      string         `json:"name"`
+	Signature string         `json:"signature"`
+	Selector  string         `json:"selector"`
+	Args      []DecodedValue `json:"args"`
+}
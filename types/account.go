@@ -0,0 +1,54 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+type EtherBalance struct {
+	Account string   `json:"account"`
+	Balance *big.Int `json:"balance"`
+}
+
+// EtherTx covers the txlist, txlistinternal, tokentx, tokennfttx and token1155tx
+// answers, fields an action does not return are left zero.
+type EtherTx struct {
+	BlockNumber       uint64    `json:"blockNumber"`
+	TimeStamp         time.Time `json:"timeStamp"`
+	Hash              string    `json:"hash"`
+	Nonce             uint64    `json:"nonce"`
+	BlockHash         string    `json:"blockHash"`
+	TransactionIndex  uint64    `json:"transactionIndex"`
+	From              string    `json:"from"`
+	To                string    `json:"to"`
+	Value             *big.Int  `json:"value"`
+	Gas               *big.Int  `json:"gas"`
+	GasPrice          *big.Int  `json:"gasPrice"`
+	GasUsed           *big.Int  `json:"gasUsed"`
+	CumulativeGasUsed *big.Int  `json:"cumulativeGasUsed"`
+	IsError           bool      `json:"isError"`
+	TxReceiptStatus   string    `json:"txreceipt_status"`
+	Input             string    `json:"input"`
+	ContractAddress   string    `json:"contractAddress"`
+	Confirmations     uint64    `json:"confirmations"`
+	MethodId          string    `json:"methodId"`
+	FunctionName      string    `json:"functionName"`
+
+	// internal transactions
+	Type    string `json:"type"`
+	TraceId string `json:"traceId"`
+	ErrCode string `json:"errCode"`
+
+	// token transfers
+	TokenName    string   `json:"tokenName"`
+	TokenSymbol  string   `json:"tokenSymbol"`
+	TokenDecimal uint8    `json:"tokenDecimal"`
+	TokenID      *big.Int `json:"tokenID"`
+	TokenValue   *big.Int `json:"tokenValue"`
+}
+
+type EtherMinedBlock struct {
+	BlockNumber uint64    `json:"blockNumber"`
+	TimeStamp   time.Time `json:"timeStamp"`
+	BlockReward *big.Int  `json:"blockReward"`
+}
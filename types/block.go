@@ -0,0 +1,29 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// EtherBlockReward is the getblockreward answer, rewards are in wei.
+type EtherBlockReward struct {
+	BlockNumber          uint64        `json:"blockNumber"`
+	TimeStamp            time.Time     `json:"timeStamp"`
+	BlockMiner           string        `json:"blockMiner"`
+	BlockReward          *big.Int      `json:"blockReward"`
+	Uncles               []*EtherUncle `json:"uncles"`
+	UncleInclusionReward *big.Int      `json:"uncleInclusionReward"`
+}
+
+type EtherUncle struct {
+	Miner         string   `json:"miner"`
+	UnclePosition uint64   `json:"unclePosition"`
+	BlockReward   *big.Int `json:"blockreward"`
+}
+
+type EtherBlockCountdown struct {
+	CurrentBlock      uint64  `json:"CurrentBlock"`
+	CountdownBlock    uint64  `json:"CountdownBlock"`
+	RemainingBlock    uint64  `json:"RemainingBlock"`
+	EstimateTimeInSec float64 `json:"EstimateTimeInSec"`
+}
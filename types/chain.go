@@ -0,0 +1,144 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// Chain describes an EVM chain and how every source refers to it. ExplorerAPI is
+// the etherscan-family api of the chain, CoinGeckoPlatform the CoinGecko asset
+// platform ID and CMCPlatform the slug CoinMarketCap gives the chain's platform.
+// Empty fields mean the source does not cover the chain.
+type Chain struct {
+	ID                uint64         `json:"id"`
+	Name              string         `json:"name"`
+	Aliases           []string       `json:"aliases"`
+	NativeCurrency    NativeCurrency `json:"nativeCurrency"`
+	ExplorerAPI       string         `json:"explorerApi"`
+	CoinGeckoPlatform string         `json:"coinGeckoPlatform"`
+	CMCPlatform       string         `json:"cmcPlatform"`
+}
+
+var (
+	ether = NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+
+	chainsLock sync.RWMutex
+	chains     = map[uint64]*Chain{}
+	chainNames = map[string]*Chain{}
+)
+
+func init() {
+	for _, chain := range []*Chain{
+		{
+			ID: 1, Name: "ethereum", Aliases: []string{"eth", "mainnet"}, NativeCurrency: ether,
+			ExplorerAPI: "https://api.etherscan.io/api", CoinGeckoPlatform: "ethereum", CMCPlatform: "ethereum",
+		},
+		{
+			ID: 10, Name: "optimism", Aliases: []string{"op", "optimistic-ethereum"}, NativeCurrency: ether,
+			ExplorerAPI: "https://api-optimistic.etherscan.io/api", CoinGeckoPlatform: "optimistic-ethereum", CMCPlatform: "optimism-ethereum",
+		},
+		{
+			ID: 56, Name: "bsc", Aliases: []string{"bnb", "binance-smart-chain", "bscscan"},
+			NativeCurrency: NativeCurrency{Name: "BNB", Symbol: "BNB", Decimals: 18},
+			ExplorerAPI:    "https://api.bscscan.com/api", CoinGeckoPlatform: "binance-smart-chain", CMCPlatform: "bnb",
+		},
+		{
+			ID: 100, Name: "gnosis", Aliases: []string{"xdai", "gnosisscan"},
+			NativeCurrency: NativeCurrency{Name: "xDAI", Symbol: "XDAI", Decimals: 18},
+			ExplorerAPI:    "https://api.gnosisscan.io/api", CoinGeckoPlatform: "xdai", CMCPlatform: "xdai",
+		},
+		{
+			ID: 137, Name: "polygon", Aliases: []string{"matic", "polygon-pos", "polygonscan"},
+			NativeCurrency: NativeCurrency{Name: "POL", Symbol: "POL", Decimals: 18},
+			ExplorerAPI:    "https://api.polygonscan.com/api", CoinGeckoPlatform: "polygon-pos", CMCPlatform: "polygon",
+		},
+		{
+			ID: 250, Name: "fantom", Aliases: []string{"ftm", "ftmscan"},
+			NativeCurrency: NativeCurrency{Name: "Fantom", Symbol: "FTM", Decimals: 18},
+			ExplorerAPI:    "https://api.ftmscan.com/api", CoinGeckoPlatform: "fantom", CMCPlatform: "fantom",
+		},
+		{
+			ID: 8453, Name: "base", Aliases: []string{"basescan"}, NativeCurrency: ether,
+			ExplorerAPI: "https://api.basescan.org/api", CoinGeckoPlatform: "base",
+		},
+		{
+			ID: 42161, Name: "arbitrum", Aliases: []string{"arb", "arbitrum-one", "arbiscan"}, NativeCurrency: ether,
+			ExplorerAPI: "https://api.arbiscan.io/api", CoinGeckoPlatform: "arbitrum-one", CMCPlatform: "arbitrum",
+		},
+		{
+			ID: 43114, Name: "avalanche", Aliases: []string{"avax", "avalanche-c", "snowtrace"},
+			NativeCurrency: NativeCurrency{Name: "Avalanche", Symbol: "AVAX", Decimals: 18},
+			ExplorerAPI:    "https://api.snowscan.xyz/api", CoinGeckoPlatform: "avalanche", CMCPlatform: "avalanche",
+		},
+		{
+			ID: 59144, Name: "linea", Aliases: []string{"lineascan"}, NativeCurrency: ether,
+			ExplorerAPI: "https://api.lineascan.build/api", CoinGeckoPlatform: "linea",
+		},
+	} {
+		RegisterChain(chain)
+	}
+}
+
+// RegisterChain adds chain to the registry, replacing a chain of the same ID.
+// Its name and aliases resolve to it case-insensitively, as does its decimal ID.
+func RegisterChain(chain *Chain) {
+	chainsLock.Lock()
+	defer chainsLock.Unlock()
+
+	if old, ok := chains[chain.ID]; ok {
+		for _, name := range old.names() {
+			delete(chainNames, name)
+		}
+	}
+
+	chains[chain.ID] = chain
+	for _, name := range chain.names() {
+		chainNames[name] = chain
+	}
+}
+
+func ChainByID(id uint64) (*Chain, bool) {
+	chainsLock.RLock()
+	defer chainsLock.RUnlock()
+
+	chain, ok := chains[id]
+	return chain, ok
+}
+
+// ChainByName looks chain up by name, alias or decimal chain ID.
+func ChainByName(name string) (*Chain, bool) {
+	chainsLock.RLock()
+	defer chainsLock.RUnlock()
+
+	chain, ok := chainNames[strings.ToLower(strings.TrimSpace(name))]
+	return chain, ok
+}
+
+// Chains returns the registered chains ordered by ID.
+func Chains() []*Chain {
+	chainsLock.RLock()
+	defer chainsLock.RUnlock()
+
+	list := make([]*Chain, 0, len(chains))
+	for _, chain := range chains {
+		list = append(list, chain)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (c *Chain) names() []string {
+	names := []string{strings.ToLower(c.Name), strconv.FormatUint(c.ID, 10)}
+	for _, alias := range c.Aliases {
+		names = append(names, strings.ToLower(alias))
+	}
+	return names
+}
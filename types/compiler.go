@@ -0,0 +1,63 @@
+package types
+
+import "fmt"
+
+type EVMVersion string
+
+const (
+	EVMHomestead        EVMVersion = "homestead"
+	EVMTangerineWhistle EVMVersion = "tangerineWhistle"
+	EVMSpuriousDragon   EVMVersion = "spuriousDragon"
+	EVMByzantium        EVMVersion = "byzantium"
+	EVMConstantinople   EVMVersion = "constantinople"
+	EVMPetersburg       EVMVersion = "petersburg"
+	EVMIstanbul         EVMVersion = "istanbul"
+	EVMBerlin           EVMVersion = "berlin"
+	EVMLondon           EVMVersion = "london"
+	EVMParis            EVMVersion = "paris"
+	EVMShanghai         EVMVersion = "shanghai"
+	EVMCancun           EVMVersion = "cancun"
+	EVMPrague           EVMVersion = "prague"
+)
+
+// Semver is a compiler release. Prerelease holds e.g. "nightly.2018.5.16" or
+// vyper's "b17", Commit the short commit hash solc builds are tagged with.
+type Semver struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+}
+
+func (v Semver) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		version += "-" + v.Prerelease
+	}
+	return version
+}
+
+// Less compares the release numbers of v and o, ignoring prerelease and commit.
+func (v Semver) Less(o Semver) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// CompilerInfo is the compiler metadata of EtherSourceCode in typed form.
+// EVMVersion is the version the compiler defaulted to when etherscan reports
+// "Default", License an SPDX identifier and Libraries maps names to addresses.
+type CompilerInfo struct {
+	Compiler   string            `json:"compiler"`
+	Version    Semver            `json:"version"`
+	Nightly    bool              `json:"nightly"`
+	Optimizer  Optimizer         `json:"optimizer"`
+	EVMVersion EVMVersion        `json:"evmVersion"`
+	License    string            `json:"license"`
+	Libraries  map[string]string `json:"libraries,omitempty"`
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EtherContractCreation struct {
+	ContractAddress string    `json:"contractAddress"`
+	ContractCreator string    `json:"contractCreator"`
+	TxHash          string    `json:"txHash"`
+	BlockNumber     uint64    `json:"blockNumber"`
+	TimeStamp       time.Time `json:"timestamp"`
+	ContractFactory string    `json:"contractFactory"`
+}
+
+type VerifyState string
+
+const (
+	VerifyPending VerifyState = "pending"
+	VerifyPass    VerifyState = "pass"
+	VerifyFail    VerifyState = "fail"
+)
+
+// EtherVerifyStatus is the state of a source or proxy verification, Message is etherscan's own wording.
+type EtherVerifyStatus struct {
+	State   VerifyState `json:"state"`
+	Message string      `json:"message"`
+}
+
+// ResolvedContract follows a proxy to its implementation. Chain starts at the
+// address asked for and Sources holds the source code of every address in Chain.
+type ResolvedContract struct {
+	Address        string             `json:"address"`
+	Chain          []string           `json:"chain"`
+	Sources        []*EtherSourceCode `json:"sources"`
+	Implementation *EtherSourceCode   `json:"implementation"`
+	ABI            string             `json:"abi"`
+}
+
+// ContractSources is the file tree of verified source code. Sources maps paths
+// to file contents and Libraries maps a file to library names and addresses.
+type ContractSources struct {
+	Language   string                       `json:"language"`
+	Sources    map[string]string            `json:"sources"`
+	Settings   json.RawMessage              `json:"settings,omitempty"`
+	Remappings []string                     `json:"remappings,omitempty"`
+	Optimizer  Optimizer                    `json:"optimizer"`
+	EVMVersion string                       `json:"evmVersion,omitempty"`
+	Libraries  map[string]map[string]string `json:"libraries,omitempty"`
+}
+
+type Optimizer struct {
+	Enabled bool `json:"enabled"`
+	Runs    int  `json:"runs"`
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// EtherLog is a getLogs record, Event is only set when the log was decoded.
+type EtherLog struct {
+	Address          string        `json:"address"`
+	Topics           []string      `json:"topics"`
+	Data             string        `json:"data"`
+	BlockNumber      uint64        `json:"blockNumber"`
+	BlockHash        string        `json:"blockHash"`
+	TimeStamp        time.Time     `json:"timeStamp"`
+	GasPrice         *big.Int      `json:"gasPrice"`
+	GasUsed          *big.Int      `json:"gasUsed"`
+	LogIndex         uint64        `json:"logIndex"`
+	TransactionHash  string        `json:"transactionHash"`
+	TransactionIndex uint64        `json:"transactionIndex"`
+	Event            *DecodedEvent `json:"event,omitempty"`
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// EtherRPCResult is the json-rpc envelope of the proxy module. Status and Message
+// are only set when etherscan itself refuses the call, e.g. when rate limited.
+type EtherRPCResult struct {
+	JsonRPC string         `json:"jsonrpc"`
+	ID      interface{}    `json:"id"`
+	Result  interface{}    `json:"result"`
+	Error   *EtherRPCError `json:"error"`
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+}
+
+type EtherRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// EtherBlock is an eth_getBlockByNumber answer, Transactions is only set when full
+// transactions were asked for and TransactionHashes otherwise.
+type EtherBlock struct {
+	Number            uint64        `json:"number"`
+	Hash              string        `json:"hash"`
+	ParentHash        string        `json:"parentHash"`
+	Nonce             string        `json:"nonce"`
+	Sha3Uncles        string        `json:"sha3Uncles"`
+	LogsBloom         string        `json:"logsBloom"`
+	TransactionsRoot  string        `json:"transactionsRoot"`
+	StateRoot         string        `json:"stateRoot"`
+	ReceiptsRoot      string        `json:"receiptsRoot"`
+	Miner             string        `json:"miner"`
+	Difficulty        *big.Int      `json:"difficulty"`
+	TotalDifficulty   *big.Int      `json:"totalDifficulty"`
+	ExtraData         string        `json:"extraData"`
+	Size              uint64        `json:"size"`
+	GasLimit          *big.Int      `json:"gasLimit"`
+	GasUsed           *big.Int      `json:"gasUsed"`
+	BaseFeePerGas     *big.Int      `json:"baseFeePerGas"`
+	TimeStamp         time.Time     `json:"timestamp"`
+	Uncles            []string      `json:"uncles"`
+	Transactions      []*EtherRPCTx `json:"transactions,omitempty"`
+	TransactionHashes []string      `json:"transactionHashes,omitempty"`
+}
+
+// EtherRPCTx is a transaction as eth_getTransactionByHash answers it, BlockNumber
+// and BlockHash are zero while it is pending.
+type EtherRPCTx struct {
+	Hash                 string   `json:"hash"`
+	BlockHash            string   `json:"blockHash"`
+	BlockNumber          uint64   `json:"blockNumber"`
+	TransactionIndex     uint64   `json:"transactionIndex"`
+	Type                 uint64   `json:"type"`
+	ChainID              *big.Int `json:"chainId"`
+	Nonce                uint64   `json:"nonce"`
+	From                 string   `json:"from"`
+	To                   string   `json:"to"`
+	Value                *big.Int `json:"value"`
+	Gas                  *big.Int `json:"gas"`
+	GasPrice             *big.Int `json:"gasPrice"`
+	MaxFeePerGas         *big.Int `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas"`
+	Input                string   `json:"input"`
+	V                    string   `json:"v"`
+	R                    string   `json:"r"`
+	S                    string   `json:"s"`
+}
+
+// EtherReceipt is an eth_getTransactionReceipt answer, Status is 1 on success.
+type EtherReceipt struct {
+	TransactionHash   string      `json:"transactionHash"`
+	TransactionIndex  uint64      `json:"transactionIndex"`
+	BlockHash         string      `json:"blockHash"`
+	BlockNumber       uint64      `json:"blockNumber"`
+	From              string      `json:"from"`
+	To                string      `json:"to"`
+	ContractAddress   string      `json:"contractAddress"`
+	CumulativeGasUsed *big.Int    `json:"cumulativeGasUsed"`
+	GasUsed           *big.Int    `json:"gasUsed"`
+	EffectiveGasPrice *big.Int    `json:"effectiveGasPrice"`
+	Logs              []*EtherLog `json:"logs"`
+	LogsBloom         string      `json:"logsBloom"`
+	Status            uint64      `json:"status"`
+	Type              uint64      `json:"type"`
+}
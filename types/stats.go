@@ -0,0 +1,46 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// EtherGasOracle holds the gas prices suggested for the next block, in gwei.
+// GasUsedRatio is the ratio of gas used to the gas limit of the latest blocks.
+type EtherGasOracle struct {
+	LastBlock       uint64    `json:"LastBlock"`
+	SafeGasPrice    float64   `json:"SafeGasPrice"`
+	ProposeGasPrice float64   `json:"ProposeGasPrice"`
+	FastGasPrice    float64   `json:"FastGasPrice"`
+	SuggestBaseFee  float64   `json:"suggestBaseFee"`
+	GasUsedRatio    []float64 `json:"gasUsedRatio"`
+}
+
+// EtherSupply is the ethsupply2 answer, amounts are in wei.
+type EtherSupply struct {
+	EthSupply      *big.Int `json:"EthSupply"`
+	Eth2Staking    *big.Int `json:"Eth2Staking"`
+	BurntFees      *big.Int `json:"BurntFees"`
+	WithdrawnTotal *big.Int `json:"WithdrawnTotal"`
+}
+
+type EtherPrice struct {
+	ETHBTC          float64   `json:"ethbtc"`
+	ETHBTCTimeStamp time.Time `json:"ethbtc_timestamp"`
+	ETHUSD          float64   `json:"ethusd"`
+	ETHUSDTimeStamp time.Time `json:"ethusd_timestamp"`
+}
+
+// EtherChainSize is the size in bytes of the blockchain data of a node on a day.
+type EtherChainSize struct {
+	BlockNumber    uint64    `json:"blockNumber"`
+	ChainTimeStamp time.Time `json:"chainTimeStamp"`
+	ChainSize      *big.Int  `json:"chainSize"`
+	ClientType     string    `json:"clientType"`
+	SyncMode       string    `json:"syncMode"`
+}
+
+type EtherNodeCount struct {
+	UTCDate        time.Time `json:"UTCDate"`
+	TotalNodeCount uint64    `json:"TotalNodeCount"`
+}
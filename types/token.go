@@ -0,0 +1,55 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+// TokenAmount is an amount of token base units, Decimals places scale it to whole tokens.
+type TokenAmount struct {
+	Value    *big.Int `json:"value"`
+	Decimals uint8    `json:"decimals"`
+}
+
+// Float returns the amount in whole tokens.
+func (a *TokenAmount) Float() *big.Float {
+	if a.Value == nil {
+		return new(big.Float).SetPrec(256)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.Decimals)), nil)
+	return new(big.Float).SetPrec(256).Quo(new(big.Float).SetInt(a.Value), new(big.Float).SetInt(scale))
+}
+
+// String formats the amount in whole tokens without losing precision, e.g. "1.5".
+func (a *TokenAmount) String() string {
+	if a.Value == nil {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(a.Value).String()
+	sign := ""
+	if a.Value.Sign() < 0 {
+		sign = "-"
+	}
+
+	if a.Decimals == 0 {
+		return sign + digits
+	}
+
+	if pad := int(a.Decimals) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(a.Decimals)
+	fraction := strings.TrimRight(digits[point:], "0")
+	if fraction == "" {
+		return sign + digits[:point]
+	}
+	return sign + digits[:point] + "." + fraction
+}
+
+type EtherTokenHolder struct {
+	Address string       `json:"address"`
+	Balance *TokenAmount `json:"balance"`
+}
@@ -17,6 +17,7 @@ type TokenInfo struct {
 	Discord     string `json:"discord"`
 	Github      string `json:"github"`
 	Description string `json:"description"`
+	TotalSupply string `json:"totalSupply"`
 }
 
 type PlatformForDataSource string
@@ -1586,3 +1587,11 @@ type MarketMap struct {
 	LastUpdatedAt time.Time
 	Lock          sync.RWMutex
 }
+
+type CoinGeckoError struct {
+	Error  string `json:"error"`
+	Status struct {
+		ErrorCode    int    `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"status"`
+}